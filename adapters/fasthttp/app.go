@@ -1,7 +1,11 @@
 package fasthttp
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
+	"net/url"
+	"time"
 
 	"github.com/hemant-mann/lumora-go/core"
 	"github.com/hemant-mann/lumora-go/services"
@@ -9,23 +13,39 @@ import (
 )
 
 type App struct {
-	server      *fasthttp.Server
-	middlewares []core.Middleware
-	router      *Router
-	services    *services.Container
+	server       *fasthttp.Server
+	middlewares  []core.Middleware
+	router       *Router
+	services     *services.Container
+	notFound     core.Handler
+	formOptions  *core.FormOptions
+	codecs       *core.CodecRegistry
+	jsonEngine   core.JSONEngine
+	errorHandler core.ErrorHandler
+	routes       []*core.Route
+	urlSigner    *core.URLSigner
 }
 
 // New creates a new fasthttp adapter app
 func New() *App {
 	app := &App{
-		middlewares: []core.Middleware{},
-		router:      NewRouter(),
-		services:    services.NewContainer(),
+		middlewares:  []core.Middleware{},
+		router:       NewRouter(),
+		services:     services.NewContainer(),
+		notFound:     core.NotFoundHandler,
+		formOptions:  core.DefaultFormOptions(),
+		codecs:       core.NewCodecRegistry(),
+		jsonEngine:   core.StdJSON{},
+		errorHandler: core.DefaultErrorHandler,
 	}
 
 	// Wrap the router handler with our middleware handler
+	// Request bodies are streamed so uploads can be read without buffering them in memory
+	// Multipart forms are parsed by core, so fasthttp must not pre-parse (and buffer) them
 	app.server = &fasthttp.Server{
-		Handler: app.wrapHandler(app.router.Handler()),
+		Handler:                      app.wrapHandler(app.router.Handler()),
+		StreamRequestBody:            true,
+		DisablePreParseMultipartForm: true,
 	}
 
 	return app
@@ -35,59 +55,153 @@ func (a *App) Use(middleware ...core.Middleware) {
 	a.middlewares = append(a.middlewares, middleware...)
 }
 
-func (a *App) Handle(method, path string, handler core.Handler, middlewares ...core.Middleware) {
+func (a *App) Handle(method, path string, handler core.Handler, middlewares ...core.Middleware) *core.Route {
 	// Combine app-level and route-level middlewares
 	allMiddlewares := append(a.middlewares, middlewares...)
 
-	// Apply middlewares to handler
-	finalHandler := core.Apply(handler, allMiddlewares...)
+	// Apply middlewares to handler; hooks record their schemas on the route as they are applied
+	route := core.NewRoute(method, path)
+	finalHandler := core.ApplyRoute(route, handler, allMiddlewares...)
+	a.routes = append(a.routes, route)
 
 	// Register with router - create a fasthttp handler that converts context
-	a.router.Handle(method, path, func(ctx *fasthttp.RequestCtx) {
+	a.router.Handle(method, path, a.serve(finalHandler))
+
+	return route
+}
+
+// serve converts a core handler into a fasthttp.RequestHandler
+func (a *App) serve(finalHandler core.Handler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
 		coreCtx := NewContext(ctx, a.services)
 
 		// Set app-level services in context for UseServices middleware
 		coreCtx.Set("_app_services", a.services)
+		core.SetAppValues(coreCtx, core.AppValues{
+			Codecs:     a.codecs,
+			JSONEngine: a.jsonEngine,
+			URLBuilder: a,
+			URLSigner:  a.urlSigner,
+		})
 
 		// Extract path parameters from UserValues (fasthttp/router stores them here)
 		if ctxImpl, ok := coreCtx.(*contextImpl); ok {
 			params := make(map[string]string)
 			ctx.VisitUserValues(func(key []byte, value any) {
 				if str, ok := value.(string); ok {
+					// The router matches the escaped path; unescape values like the other adapters
+					if unescaped, err := url.PathUnescape(str); err == nil {
+						str = unescaped
+					}
 					params[string(key)] = str
 				}
 			})
 			ctxImpl.SetParams(params)
+
+			// Apply form limits and remove temporary upload files once the request ends
+			ctxImpl.SetFormOptions(a.formOptions)
+			defer ctxImpl.Cleanup()
 		}
 
 		// Call our core handler - orchestrator handles response and error
 		resp, handlerErr := finalHandler(coreCtx)
 		if err := core.HandleResponse(coreCtx, resp, handlerErr); err != nil {
-			// If error middleware didn't handle it, send a default error response
-			// This should rarely happen if error middleware is properly configured
-			ctx.Error(err.Error(), fasthttp.StatusInternalServerError)
+			// Errors no middleware handled go to the app's error handler
+			a.errorHandler(coreCtx, err)
 		}
-	})
+	}
+}
+
+func (a *App) Get(path string, handler core.Handler, middlewares ...core.Middleware) *core.Route {
+	return a.Handle("GET", path, handler, middlewares...)
+}
+
+func (a *App) Post(path string, handler core.Handler, middlewares ...core.Middleware) *core.Route {
+	return a.Handle("POST", path, handler, middlewares...)
+}
+
+func (a *App) Put(path string, handler core.Handler, middlewares ...core.Middleware) *core.Route {
+	return a.Handle("PUT", path, handler, middlewares...)
+}
+
+func (a *App) Delete(path string, handler core.Handler, middlewares ...core.Middleware) *core.Route {
+	return a.Handle("DELETE", path, handler, middlewares...)
+}
+
+func (a *App) Patch(path string, handler core.Handler, middlewares ...core.Middleware) *core.Route {
+	return a.Handle("PATCH", path, handler, middlewares...)
 }
 
-func (a *App) Get(path string, handler core.Handler, middlewares ...core.Middleware) {
-	a.Handle("GET", path, handler, middlewares...)
+// Static serves files from fsys under the given path prefix
+// Files are served by core.StaticHandler so behavior is identical across adapters
+func (a *App) Static(prefix string, fsys fs.FS, options *core.StaticOptions) {
+	handler := core.StaticHandler(fsys, options)
+	pattern := core.StaticPattern(prefix)
+	a.Handle("GET", pattern, handler)
+	a.Handle("HEAD", pattern, handler)
 }
 
-func (a *App) Post(path string, handler core.Handler, middlewares ...core.Middleware) {
-	a.Handle("POST", path, handler, middlewares...)
+// NotFound sets the handler for requests that match no route
+func (a *App) NotFound(handler core.Handler) {
+	a.notFound = handler
 }
 
-func (a *App) Put(path string, handler core.Handler, middlewares ...core.Middleware) {
-	a.Handle("PUT", path, handler, middlewares...)
+// SPA serves a single-page-app from fsys through the NotFound handler
+func (a *App) SPA(fsys fs.FS, options *core.SPAOptions) error {
+	handler, err := core.SPAHandler(fsys, options)
+	if err != nil {
+		return err
+	}
+	a.NotFound(handler)
+	return nil
+}
+
+// SetFormOptions sets the limits used when parsing request forms
+func (a *App) SetFormOptions(options *core.FormOptions) {
+	a.formOptions = options
+}
+
+// Codecs returns the codec registry used to negotiate response formats
+func (a *App) Codecs() *core.CodecRegistry {
+	return a.codecs
+}
+
+// SetJSONEngine sets the JSON implementation used for every JSON encode and decode
+func (a *App) SetJSONEngine(engine core.JSONEngine) {
+	a.jsonEngine = engine
+	a.codecs.SetJSONEngine(engine)
+}
+
+// SetErrorHandler sets the last-resort handler for errors left after the middleware chain
+func (a *App) SetErrorHandler(handler core.ErrorHandler) {
+	a.errorHandler = handler
+}
+
+// Routes returns the registered routes in registration order
+func (a *App) Routes() []*core.Route {
+	return a.routes
+}
+
+// URL builds the path of the route named with Route.WithName
+func (a *App) URL(name string, params map[string]string, query url.Values) (string, error) {
+	return core.BuildURL(a.routes, name, params, query)
 }
 
-func (a *App) Delete(path string, handler core.Handler, middlewares ...core.Middleware) {
-	a.Handle("DELETE", path, handler, middlewares...)
+// SetURLSigner sets the signer used by SignedURL and the signedurl middleware
+func (a *App) SetURLSigner(signer *core.URLSigner) {
+	a.urlSigner = signer
 }
 
-func (a *App) Patch(path string, handler core.Handler, middlewares ...core.Middleware) {
-	a.Handle("PATCH", path, handler, middlewares...)
+// SignedURL builds the path of a named route signed to expire after ttl
+func (a *App) SignedURL(name string, params map[string]string, ttl time.Duration) (string, error) {
+	if a.urlSigner == nil {
+		return "", errors.New("no url signer set: call SetURLSigner first")
+	}
+	path, err := a.URL(name, params, nil)
+	if err != nil {
+		return "", err
+	}
+	return a.urlSigner.Sign(path, ttl, nil)
 }
 
 func (a *App) Services() *services.Container {
@@ -95,6 +209,10 @@ func (a *App) Services() *services.Container {
 }
 
 func (a *App) Start(addr string) error {
+	// Unmatched requests go through app-level middleware to the NotFound handler
+	// The chain is built once, so set NotFound and app-level middleware before starting
+	a.router.NotFound(a.serve(core.Apply(a.notFound, a.middlewares...)))
+
 	fmt.Printf("Server starting on %s\n", addr)
 	return a.server.ListenAndServe(addr)
 }
@@ -3,8 +3,9 @@ package fasthttp
 import (
 	"bytes"
 	"context"
-	"encoding/json"
+	"crypto/tls"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 
@@ -18,18 +19,28 @@ type contextImpl struct {
 	params   map[string]string
 	values   map[string]any
 	reqCtx   context.Context
+	cancel   context.CancelFunc
 	services *services.Container
+	form     *core.Form
+	// written is shared with contexts derived by WithContext
+	written *bool
 }
 
 // NewContext creates a new context from fasthttp.RequestCtx
 func NewContext(ctx *fasthttp.RequestCtx, svcs *services.Container) core.Context {
-	return &contextImpl{
+	// The request context ends with the server, and when a read of the body shows the client has gone
+	reqCtx, cancel := context.WithCancel(ctx)
+	c := &contextImpl{
 		ctx:      ctx,
 		params:   make(map[string]string),
 		values:   make(map[string]any),
-		reqCtx:   context.Background(),
+		reqCtx:   reqCtx,
+		cancel:   cancel,
 		services: svcs,
+		written:  new(bool),
 	}
+	c.form = core.NewForm(c.Request)
+	return c
 }
 
 func (c *contextImpl) Request() *http.Request {
@@ -39,6 +50,14 @@ func (c *contextImpl) Request() *http.Request {
 	req.URL = &url.URL{
 		Path:     string(c.ctx.Path()),
 		RawQuery: string(c.ctx.QueryArgs().QueryString()),
+		// Keep the path as sent, so EscapedPath matches the URL the client requested
+		RawPath: string(c.ctx.URI().PathOriginal()),
+	}
+	req.Host = string(c.ctx.Host())
+	req.RemoteAddr = c.ctx.RemoteAddr().String()
+	if c.ctx.IsTLS() {
+		// Only presence matters: it marks the request as served over TLS
+		req.TLS = &tls.ConnectionState{}
 	}
 	req.Header = make(http.Header)
 	allHeaders := c.ctx.Request.Header.All()
@@ -47,12 +66,35 @@ func (c *contextImpl) Request() *http.Request {
 			req.Header.Add(string(key), string(value))
 		}
 	}
+	// The body is read on demand, so inspecting the request doesn't buffer a streamed upload
+	req.Body = &lazyBody{open: c.BodyReader}
+	req.ContentLength = int64(c.ctx.Request.Header.ContentLength())
+	if req.ContentLength < 0 {
+		req.ContentLength = -1
+	}
 	return req
 }
 
+// lazyBody opens the request body on the first read
+type lazyBody struct {
+	open func() io.Reader
+	r    io.Reader
+}
+
+func (b *lazyBody) Read(p []byte) (int, error) {
+	if b.r == nil {
+		b.r = b.open()
+	}
+	return b.r.Read(p)
+}
+
+func (b *lazyBody) Close() error {
+	return nil
+}
+
 func (c *contextImpl) Response() http.ResponseWriter {
 	// Return a wrapper that writes to fasthttp response
-	return &responseWriter{ctx: c.ctx}
+	return &responseWriter{ctx: c.ctx, written: c.written}
 }
 
 func (c *contextImpl) Get(key string) (any, bool) {
@@ -68,6 +110,14 @@ func (c *contextImpl) Param(name string) string {
 	return c.params[name]
 }
 
+func (c *contextImpl) Params() map[string]string {
+	params := make(map[string]string, len(c.params))
+	for name, value := range c.params {
+		params[name] = value
+	}
+	return params
+}
+
 func (c *contextImpl) Query(name string) string {
 	return string(c.ctx.QueryArgs().Peek(name))
 }
@@ -81,13 +131,18 @@ func (c *contextImpl) SetHeader(name, value string) {
 }
 
 func (c *contextImpl) Status(code int) {
+	*c.written = true
 	c.ctx.SetStatusCode(code)
 }
 
+func (c *contextImpl) Written() bool {
+	return *c.written
+}
+
 func (c *contextImpl) JSON(code int, data any) error {
 	c.SetHeader("Content-Type", "application/json")
 	c.Status(code)
-	encoder := json.NewEncoder(c.ctx.Response.BodyWriter())
+	encoder := core.GetJSONEngine(c).NewEncoder(c.ctx.Response.BodyWriter())
 	return encoder.Encode(data)
 }
 
@@ -99,8 +154,11 @@ func (c *contextImpl) String(code int, format string, values ...any) error {
 }
 
 func (c *contextImpl) BindJSON(dest any) error {
-	decoder := json.NewDecoder(bytes.NewReader(c.ctx.PostBody()))
-	return decoder.Decode(dest)
+	return core.BindJSON(c, dest)
+}
+
+func (c *contextImpl) Bind(dest any) error {
+	return core.Bind(c, dest)
 }
 
 func (c *contextImpl) Context() context.Context {
@@ -113,7 +171,10 @@ func (c *contextImpl) WithContext(ctx context.Context) core.Context {
 		params:   c.params,
 		values:   c.values,
 		reqCtx:   ctx,
+		cancel:   c.cancel,
 		services: c.services,
+		form:     c.form,
+		written:  c.written,
 	}
 	return newCtx
 }
@@ -158,13 +219,90 @@ func (c *contextImpl) SetParams(params map[string]string) {
 }
 
 func (c *contextImpl) RequestBody() ([]byte, error) {
-	// In fasthttp, PostBody() returns the body as bytes
-	return c.ctx.PostBody(), nil
+	// Read the body, streamed or not, up to the form options' MaxBodySize
+	body, err := c.form.ReadBody(c.BodyReader())
+	if err != nil {
+		return nil, err
+	}
+	// Keep the body so it can be read again, e.g. by a handler after a validating middleware
+	c.ctx.Request.SetBodyRaw(body)
+	return body, nil
+}
+
+func (c *contextImpl) BodyReader() io.Reader {
+	// The server streams request bodies, so large uploads are not buffered
+	if stream := c.ctx.RequestBodyStream(); stream != nil {
+		return &streamReader{r: stream, remaining: int64(c.ctx.Request.Header.ContentLength()), cancel: c.cancel}
+	}
+	return bytes.NewReader(c.ctx.PostBody())
+}
+
+// streamReader cancels the request context when the client disconnects mid-upload
+// fasthttp reports that either as a read error or as an EOF before the declared Content-Length
+type streamReader struct {
+	r io.Reader
+	// remaining counts the bytes still expected; it is negative for chunked bodies
+	remaining int64
+	cancel    context.CancelFunc
+}
+
+func (s *streamReader) Read(p []byte) (int, error) {
+	n, err := s.r.Read(p)
+	if s.remaining >= 0 {
+		s.remaining -= int64(n)
+		if err == io.EOF && s.remaining > 0 {
+			err = io.ErrUnexpectedEOF
+		}
+	}
+	if err != nil && err != io.EOF {
+		s.cancel()
+	}
+	return n, err
+}
+
+func (c *contextImpl) FormValue(name string) string {
+	return c.form.Value(name)
+}
+
+func (c *contextImpl) FormValues() (url.Values, error) {
+	return c.form.Values()
+}
+
+func (c *contextImpl) PostFormValues() (url.Values, error) {
+	return c.form.BodyValues()
+}
+
+func (c *contextImpl) MultipartForm() (*core.MultipartForm, error) {
+	return c.form.Multipart()
+}
+
+func (c *contextImpl) FormFile(name string) (*core.FileHeader, error) {
+	return c.form.File(name)
+}
+
+// SetFormOptions sets the form parsing limits (used by app)
+func (c *contextImpl) SetFormOptions(options *core.FormOptions) {
+	c.form.SetOptions(options)
+}
+
+func (c *contextImpl) URL(name string, params map[string]string, query url.Values) (string, error) {
+	return core.ContextURL(c, name, params, query)
+}
+
+func (c *contextImpl) AbsoluteURL(name string, params map[string]string, query url.Values) (string, error) {
+	return core.ContextAbsoluteURL(c, name, params, query)
+}
+
+// Cleanup releases per-request resources such as temporary upload files
+func (c *contextImpl) Cleanup() {
+	c.cancel()
+	c.form.RemoveAll()
 }
 
 // responseWriter wraps fasthttp.RequestCtx to implement http.ResponseWriter
 type responseWriter struct {
-	ctx *fasthttp.RequestCtx
+	ctx     *fasthttp.RequestCtx
+	written *bool
 }
 
 func (w *responseWriter) Header() http.Header {
@@ -176,9 +314,11 @@ func (w *responseWriter) Header() http.Header {
 }
 
 func (w *responseWriter) Write(b []byte) (int, error) {
+	*w.written = true
 	return w.ctx.Write(b)
 }
 
 func (w *responseWriter) WriteHeader(statusCode int) {
+	*w.written = true
 	w.ctx.SetStatusCode(statusCode)
 }
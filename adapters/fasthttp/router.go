@@ -41,13 +41,18 @@ func (r *Router) Handle(method, pattern string, handler fasthttp.RequestHandler)
 	}
 }
 
+// NotFound sets the handler called when no route matches
+func (r *Router) NotFound(handler fasthttp.RequestHandler) {
+	r.router.NotFound = handler
+}
+
 // Handler returns the fasthttp.RequestHandler
 func (r *Router) Handler() fasthttp.RequestHandler {
 	return r.router.Handler
 }
 
-// convertPattern converts :param format to {param} format
-// Example: /users/:id -> /users/{id}
+// convertPattern converts :param format to {param} format and *param to {param:*}
+// Example: /users/:id -> /users/{id}, /static/*filepath -> /static/{filepath:*}
 func convertPattern(pattern string) string {
 	// Simple conversion: replace :param with {param}
 	// This is a basic implementation - could be enhanced with regex for edge cases
@@ -65,6 +70,16 @@ func convertPattern(pattern string) string {
 			}
 			result += pattern[paramStart:i]
 			result += "}"
+		} else if i < len(pattern)-1 && pattern[i] == '*' && (i == 0 || pattern[i-1] == '/') {
+			// Found *param (catch-all), convert to {param:*}
+			result += "{"
+			i++ // Skip the '*'
+			paramStart := i
+			for i < len(pattern) && pattern[i] != '/' {
+				i++
+			}
+			result += pattern[paramStart:i]
+			result += ":*}"
 		} else {
 			result += string(pattern[i])
 			i++
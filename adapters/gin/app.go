@@ -1,49 +1,70 @@
 package gin
 
 import (
+	"errors"
+	"io/fs"
+	"net/url"
+	"strings"
+	"time"
+
 	"github.com/gin-gonic/gin"
 	"github.com/hemant-mann/lumora-go/core"
 	"github.com/hemant-mann/lumora-go/services"
 )
 
 type App struct {
-	engine      *gin.Engine
-	middlewares []core.Middleware
-	services    *services.Container
+	engine       *gin.Engine
+	middlewares  []core.Middleware
+	services     *services.Container
+	notFound     core.Handler
+	formOptions  *core.FormOptions
+	codecs       *core.CodecRegistry
+	jsonEngine   core.JSONEngine
+	errorHandler core.ErrorHandler
+	routes       []*core.Route
+	urlSigner    *core.URLSigner
+	statics      []staticMount
+}
+
+// staticMount serves files under a path prefix
+type staticMount struct {
+	prefix   string
+	handlers map[string]gin.HandlerFunc
 }
 
 // New creates a new gin adapter app
 func New() *App {
-	return &App{
-		engine:      gin.New(),
-		middlewares: []core.Middleware{},
-		services:    services.NewContainer(),
+	app := &App{
+		engine:       gin.New(),
+		middlewares:  []core.Middleware{},
+		services:     services.NewContainer(),
+		notFound:     core.NotFoundHandler,
+		formOptions:  core.DefaultFormOptions(),
+		codecs:       core.NewCodecRegistry(),
+		jsonEngine:   core.StdJSON{},
+		errorHandler: core.DefaultErrorHandler,
 	}
+	// Match on the escaped path, so an escaped "/" stays inside its parameter; gin unescapes the values
+	app.engine.UseRawPath = true
+
+	return app
 }
 
 func (a *App) Use(middleware ...core.Middleware) {
 	a.middlewares = append(a.middlewares, middleware...)
 }
 
-func (a *App) Handle(method, path string, handler core.Handler, middlewares ...core.Middleware) {
+func (a *App) Handle(method, path string, handler core.Handler, middlewares ...core.Middleware) *core.Route {
 	// Combine app-level and route-level middlewares
 	allMiddlewares := append(a.middlewares, middlewares...)
 	
-	// Apply middlewares to handler
-	finalHandler := core.Apply(handler, allMiddlewares...)
+	// Apply middlewares to handler; hooks record their schemas on the route as they are applied
+	route := core.NewRoute(method, path)
+	finalHandler := core.ApplyRoute(route, handler, allMiddlewares...)
+	a.routes = append(a.routes, route)
 	
-		// Convert to gin handler
-		ginHandler := func(ginCtx *gin.Context) {
-			ctx := NewContext(ginCtx, a.services)
-			// Set app-level services in context for UseServices middleware
-			ctx.Set("_app_services", a.services)
-			// Orchestrator handles response and error
-			resp, err := finalHandler(ctx)
-			if err := core.HandleResponse(ctx, resp, err); err != nil {
-				// Error will be handled by error middleware if present
-				ginCtx.Error(err)
-			}
-		}
+	// Convert to gin handler
+	ginHandler := a.serve(finalHandler)
 	
 	// Register with gin
 	switch method {
@@ -60,26 +81,163 @@ func (a *App) Handle(method, path string, handler core.Handler, middlewares ...c
 	default:
 		a.engine.Handle(method, path, ginHandler)
 	}
+
+	return route
+}
+
+// serve converts a core handler into a gin.HandlerFunc
+func (a *App) serve(finalHandler core.Handler) gin.HandlerFunc {
+	return func(ginCtx *gin.Context) {
+		ctx := NewContext(ginCtx, a.services)
+		// Set app-level services in context for UseServices middleware
+		ctx.Set("_app_services", a.services)
+		core.SetAppValues(ctx, core.AppValues{
+			Codecs:     a.codecs,
+			JSONEngine: a.jsonEngine,
+			URLBuilder: a,
+			URLSigner:  a.urlSigner,
+		})
+		// Apply form limits and remove temporary upload files once the request ends
+		if ctxImpl, ok := ctx.(*contextImpl); ok {
+			ctxImpl.SetFormOptions(a.formOptions)
+			defer ctxImpl.Cleanup()
+		}
+		// Orchestrator handles response and error
+		resp, err := finalHandler(ctx)
+		if err := core.HandleResponse(ctx, resp, err); err != nil {
+			// Record the error for gin middleware, then let the app's error handler respond
+			ginCtx.Error(err)
+			a.errorHandler(ctx, err)
+		}
+	}
+}
+
+func (a *App) Get(path string, handler core.Handler, middlewares ...core.Middleware) *core.Route {
+	return a.Handle("GET", path, handler, middlewares...)
+}
+
+func (a *App) Post(path string, handler core.Handler, middlewares ...core.Middleware) *core.Route {
+	return a.Handle("POST", path, handler, middlewares...)
+}
+
+func (a *App) Put(path string, handler core.Handler, middlewares ...core.Middleware) *core.Route {
+	return a.Handle("PUT", path, handler, middlewares...)
+}
+
+func (a *App) Delete(path string, handler core.Handler, middlewares ...core.Middleware) *core.Route {
+	return a.Handle("DELETE", path, handler, middlewares...)
+}
+
+func (a *App) Patch(path string, handler core.Handler, middlewares ...core.Middleware) *core.Route {
+	return a.Handle("PATCH", path, handler, middlewares...)
+}
+
+// Static serves files from fsys under the given path prefix
+// Files are served by core.StaticHandler so behavior is identical across adapters
+// gin panics when a catch-all route overlaps another route, so the files are served from NoRoute;
+// like the other adapters, routes registered under the prefix take precedence
+func (a *App) Static(prefix string, fsys fs.FS, options *core.StaticOptions) {
+	handler := core.StaticHandler(fsys, options)
+	pattern := core.StaticPattern(prefix)
+	mount := staticMount{
+		prefix:   strings.TrimRight(prefix, "/"),
+		handlers: make(map[string]gin.HandlerFunc),
+	}
+	for _, method := range []string{"GET", "HEAD"} {
+		route := core.NewRoute(method, pattern)
+		finalHandler := core.ApplyRoute(route, handler, a.middlewares...)
+		a.routes = append(a.routes, route)
+		mount.handlers[method] = a.serve(finalHandler)
+	}
+	a.statics = append(a.statics, mount)
+}
+
+// serveStatic serves an unmatched request from the static mount with the longest matching prefix
+func (a *App) serveStatic(ginCtx *gin.Context) bool {
+	path := ginCtx.Request.URL.Path
+	var match *staticMount
+	for i, mount := range a.statics {
+		if path != mount.prefix && !strings.HasPrefix(path, mount.prefix+"/") {
+			continue
+		}
+		if match == nil || len(mount.prefix) > len(match.prefix) {
+			match = &a.statics[i]
+		}
+	}
+	if match == nil {
+		return false
+	}
+	handler, ok := match.handlers[ginCtx.Request.Method]
+	if !ok {
+		return false
+	}
+	// Set the catch-all parameter as gin would for a "/*filepath" route
+	ginCtx.Params = append(ginCtx.Params, gin.Param{Key: core.StaticParam, Value: "/" + strings.TrimPrefix(path[len(match.prefix):], "/")})
+	handler(ginCtx)
+	return true
 }
 
-func (a *App) Get(path string, handler core.Handler, middlewares ...core.Middleware) {
-	a.Handle("GET", path, handler, middlewares...)
+// NotFound sets the handler for requests that match no route
+func (a *App) NotFound(handler core.Handler) {
+	a.notFound = handler
+}
+
+// SPA serves a single-page-app from fsys through the NotFound handler
+func (a *App) SPA(fsys fs.FS, options *core.SPAOptions) error {
+	handler, err := core.SPAHandler(fsys, options)
+	if err != nil {
+		return err
+	}
+	a.NotFound(handler)
+	return nil
 }
 
-func (a *App) Post(path string, handler core.Handler, middlewares ...core.Middleware) {
-	a.Handle("POST", path, handler, middlewares...)
+// SetFormOptions sets the limits used when parsing request forms
+func (a *App) SetFormOptions(options *core.FormOptions) {
+	a.formOptions = options
 }
 
-func (a *App) Put(path string, handler core.Handler, middlewares ...core.Middleware) {
-	a.Handle("PUT", path, handler, middlewares...)
+// Codecs returns the codec registry used to negotiate response formats
+func (a *App) Codecs() *core.CodecRegistry {
+	return a.codecs
 }
 
-func (a *App) Delete(path string, handler core.Handler, middlewares ...core.Middleware) {
-	a.Handle("DELETE", path, handler, middlewares...)
+// SetJSONEngine sets the JSON implementation used for every JSON encode and decode
+func (a *App) SetJSONEngine(engine core.JSONEngine) {
+	a.jsonEngine = engine
+	a.codecs.SetJSONEngine(engine)
 }
 
-func (a *App) Patch(path string, handler core.Handler, middlewares ...core.Middleware) {
-	a.Handle("PATCH", path, handler, middlewares...)
+// SetErrorHandler sets the last-resort handler for errors left after the middleware chain
+func (a *App) SetErrorHandler(handler core.ErrorHandler) {
+	a.errorHandler = handler
+}
+
+// Routes returns the registered routes in registration order
+func (a *App) Routes() []*core.Route {
+	return a.routes
+}
+
+// URL builds the path of the route named with Route.WithName
+func (a *App) URL(name string, params map[string]string, query url.Values) (string, error) {
+	return core.BuildURL(a.routes, name, params, query)
+}
+
+// SetURLSigner sets the signer used by SignedURL and the signedurl middleware
+func (a *App) SetURLSigner(signer *core.URLSigner) {
+	a.urlSigner = signer
+}
+
+// SignedURL builds the path of a named route signed to expire after ttl
+func (a *App) SignedURL(name string, params map[string]string, ttl time.Duration) (string, error) {
+	if a.urlSigner == nil {
+		return "", errors.New("no url signer set: call SetURLSigner first")
+	}
+	path, err := a.URL(name, params, nil)
+	if err != nil {
+		return "", err
+	}
+	return a.urlSigner.Sign(path, ttl, nil)
 }
 
 func (a *App) Services() *services.Container {
@@ -87,6 +245,15 @@ func (a *App) Services() *services.Container {
 }
 
 func (a *App) Start(addr string) error {
+	// Unmatched requests go through app-level middleware to the NotFound handler
+	// The chain is built once, so set NotFound and app-level middleware before starting
+	notFound := a.serve(core.Apply(a.notFound, a.middlewares...))
+	a.engine.NoRoute(func(ginCtx *gin.Context) {
+		if a.serveStatic(ginCtx) {
+			return
+		}
+		notFound(ginCtx)
+	})
 	return a.engine.Run(addr)
 }
 
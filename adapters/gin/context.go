@@ -1,9 +1,12 @@
 package gin
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 
 	"github.com/gin-gonic/gin"
 	"github.com/hemant-mann/lumora-go/core"
@@ -13,11 +16,14 @@ import (
 type contextImpl struct {
 	ctx      *gin.Context
 	services *services.Container
+	form     *core.Form
 }
 
 // NewContext creates a new context from gin.Context
 func NewContext(ctx *gin.Context, svcs *services.Container) core.Context {
-	return &contextImpl{ctx: ctx, services: svcs}
+	c := &contextImpl{ctx: ctx, services: svcs}
+	c.form = core.NewForm(c.Request)
+	return c
 }
 
 func (c *contextImpl) Request() *http.Request {
@@ -41,6 +47,14 @@ func (c *contextImpl) Param(name string) string {
 	return c.ctx.Param(name)
 }
 
+func (c *contextImpl) Params() map[string]string {
+	params := make(map[string]string, len(c.ctx.Params))
+	for _, param := range c.ctx.Params {
+		params[param.Key] = param.Value
+	}
+	return params
+}
+
 func (c *contextImpl) Query(name string) string {
 	return c.ctx.Query(name)
 }
@@ -57,9 +71,15 @@ func (c *contextImpl) Status(code int) {
 	c.ctx.Status(code)
 }
 
+func (c *contextImpl) Written() bool {
+	return c.ctx.Writer.Written()
+}
+
 func (c *contextImpl) JSON(code int, data any) error {
-	c.ctx.JSON(code, data)
-	return nil
+	c.SetHeader("Content-Type", "application/json")
+	c.Status(code)
+	encoder := core.GetJSONEngine(c).NewEncoder(c.ctx.Writer)
+	return encoder.Encode(data)
 }
 
 func (c *contextImpl) String(code int, format string, values ...any) error {
@@ -68,7 +88,11 @@ func (c *contextImpl) String(code int, format string, values ...any) error {
 }
 
 func (c *contextImpl) BindJSON(dest any) error {
-	return c.ctx.ShouldBindJSON(dest)
+	return core.BindJSON(c, dest)
+}
+
+func (c *contextImpl) Bind(dest any) error {
+	return core.Bind(c, dest)
 }
 
 func (c *contextImpl) Context() context.Context {
@@ -78,7 +102,7 @@ func (c *contextImpl) Context() context.Context {
 func (c *contextImpl) WithContext(ctx context.Context) core.Context {
 	newGinCtx := c.ctx.Copy()
 	newGinCtx.Request = newGinCtx.Request.WithContext(ctx)
-	return &contextImpl{ctx: newGinCtx, services: c.services}
+	return &contextImpl{ctx: newGinCtx, services: c.services, form: c.form}
 }
 
 func (c *contextImpl) Service(name string) (any, error) {
@@ -116,6 +140,54 @@ func (c *contextImpl) MustService(name string) any {
 }
 
 func (c *contextImpl) RequestBody() ([]byte, error) {
-	// Gin provides GetRawData() method which returns ([]byte, error)
-	return c.ctx.GetRawData()
+	// Read the request body, up to the form options' MaxBodySize
+	body, err := c.form.ReadBody(c.ctx.Request.Body)
+	if err != nil {
+		return nil, err
+	}
+	// Restore the body so it can be read again, e.g. by a handler after a validating middleware
+	c.ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+func (c *contextImpl) BodyReader() io.Reader {
+	return c.ctx.Request.Body
+}
+
+func (c *contextImpl) FormValue(name string) string {
+	return c.form.Value(name)
+}
+
+func (c *contextImpl) FormValues() (url.Values, error) {
+	return c.form.Values()
+}
+
+func (c *contextImpl) PostFormValues() (url.Values, error) {
+	return c.form.BodyValues()
+}
+
+func (c *contextImpl) MultipartForm() (*core.MultipartForm, error) {
+	return c.form.Multipart()
+}
+
+func (c *contextImpl) FormFile(name string) (*core.FileHeader, error) {
+	return c.form.File(name)
+}
+
+// SetFormOptions sets the form parsing limits (used by app)
+func (c *contextImpl) SetFormOptions(options *core.FormOptions) {
+	c.form.SetOptions(options)
+}
+
+func (c *contextImpl) URL(name string, params map[string]string, query url.Values) (string, error) {
+	return core.ContextURL(c, name, params, query)
+}
+
+func (c *contextImpl) AbsoluteURL(name string, params map[string]string, query url.Values) (string, error) {
+	return core.ContextAbsoluteURL(c, name, params, query)
+}
+
+// Cleanup releases per-request resources such as temporary upload files
+func (c *contextImpl) Cleanup() {
+	c.form.RemoveAll()
 }
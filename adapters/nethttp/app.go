@@ -1,27 +1,43 @@
 package nethttp
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/hemant-mann/lumora-go/core"
 	"github.com/hemant-mann/lumora-go/services"
 )
 
 type App struct {
-	mux         *http.ServeMux
-	middlewares []core.Middleware
-	router      *Router
-	services    *services.Container
+	mux          *http.ServeMux
+	middlewares  []core.Middleware
+	router       *Router
+	services     *services.Container
+	notFound     core.Handler
+	formOptions  *core.FormOptions
+	codecs       *core.CodecRegistry
+	jsonEngine   core.JSONEngine
+	errorHandler core.ErrorHandler
+	routes       []*core.Route
+	urlSigner    *core.URLSigner
 }
 
 // New creates a new net/http adapter app
 func New() *App {
 	return &App{
-		mux:         http.NewServeMux(),
-		middlewares: []core.Middleware{},
-		router:      NewRouter(),
-		services:    services.NewContainer(),
+		mux:          http.NewServeMux(),
+		middlewares:  []core.Middleware{},
+		router:       NewRouter(),
+		services:     services.NewContainer(),
+		notFound:     core.NotFoundHandler,
+		formOptions:  core.DefaultFormOptions(),
+		codecs:       core.NewCodecRegistry(),
+		jsonEngine:   core.StdJSON{},
+		errorHandler: core.DefaultErrorHandler,
 	}
 }
 
@@ -29,12 +45,14 @@ func (a *App) Use(middleware ...core.Middleware) {
 	a.middlewares = append(a.middlewares, middleware...)
 }
 
-func (a *App) Handle(method, path string, handler core.Handler, middlewares ...core.Middleware) {
+func (a *App) Handle(method, path string, handler core.Handler, middlewares ...core.Middleware) *core.Route {
 	// Combine app-level and route-level middlewares
 	allMiddlewares := append(a.middlewares, middlewares...)
 	
-	// Apply middlewares to handler
-	finalHandler := core.Apply(handler, allMiddlewares...)
+	// Apply middlewares to handler; hooks record their schemas on the route as they are applied
+	route := core.NewRoute(method, path)
+	finalHandler := core.ApplyRoute(route, handler, allMiddlewares...)
+	a.routes = append(a.routes, route)
 	
 	// Register with router - wrap handler to convert to router's expected signature
 	// Router expects a handler that returns error, but we need to call HandleResponse
@@ -42,26 +60,100 @@ func (a *App) Handle(method, path string, handler core.Handler, middlewares ...c
 		resp, err := finalHandler(ctx)
 		return core.HandleResponse(ctx, resp, err)
 	})
+
+	return route
+}
+
+func (a *App) Get(path string, handler core.Handler, middlewares ...core.Middleware) *core.Route {
+	return a.Handle(http.MethodGet, path, handler, middlewares...)
+}
+
+func (a *App) Post(path string, handler core.Handler, middlewares ...core.Middleware) *core.Route {
+	return a.Handle(http.MethodPost, path, handler, middlewares...)
+}
+
+func (a *App) Put(path string, handler core.Handler, middlewares ...core.Middleware) *core.Route {
+	return a.Handle(http.MethodPut, path, handler, middlewares...)
+}
+
+func (a *App) Delete(path string, handler core.Handler, middlewares ...core.Middleware) *core.Route {
+	return a.Handle(http.MethodDelete, path, handler, middlewares...)
+}
+
+func (a *App) Patch(path string, handler core.Handler, middlewares ...core.Middleware) *core.Route {
+	return a.Handle(http.MethodPatch, path, handler, middlewares...)
+}
+
+// Static serves files from fsys under the given path prefix
+// Files are served by core.StaticHandler so behavior is identical across adapters
+func (a *App) Static(prefix string, fsys fs.FS, options *core.StaticOptions) {
+	handler := core.StaticHandler(fsys, options)
+	pattern := core.StaticPattern(prefix)
+	a.Handle(http.MethodGet, pattern, handler)
+	a.Handle(http.MethodHead, pattern, handler)
+}
+
+// NotFound sets the handler for requests that match no route
+func (a *App) NotFound(handler core.Handler) {
+	a.notFound = handler
 }
 
-func (a *App) Get(path string, handler core.Handler, middlewares ...core.Middleware) {
-	a.Handle(http.MethodGet, path, handler, middlewares...)
+// SPA serves a single-page-app from fsys through the NotFound handler
+func (a *App) SPA(fsys fs.FS, options *core.SPAOptions) error {
+	handler, err := core.SPAHandler(fsys, options)
+	if err != nil {
+		return err
+	}
+	a.NotFound(handler)
+	return nil
+}
+
+// SetFormOptions sets the limits used when parsing request forms
+func (a *App) SetFormOptions(options *core.FormOptions) {
+	a.formOptions = options
+}
+
+// Codecs returns the codec registry used to negotiate response formats
+func (a *App) Codecs() *core.CodecRegistry {
+	return a.codecs
+}
+
+// SetJSONEngine sets the JSON implementation used for every JSON encode and decode
+func (a *App) SetJSONEngine(engine core.JSONEngine) {
+	a.jsonEngine = engine
+	a.codecs.SetJSONEngine(engine)
 }
 
-func (a *App) Post(path string, handler core.Handler, middlewares ...core.Middleware) {
-	a.Handle(http.MethodPost, path, handler, middlewares...)
+// SetErrorHandler sets the last-resort handler for errors left after the middleware chain
+func (a *App) SetErrorHandler(handler core.ErrorHandler) {
+	a.errorHandler = handler
 }
 
-func (a *App) Put(path string, handler core.Handler, middlewares ...core.Middleware) {
-	a.Handle(http.MethodPut, path, handler, middlewares...)
+// Routes returns the registered routes in registration order
+func (a *App) Routes() []*core.Route {
+	return a.routes
 }
 
-func (a *App) Delete(path string, handler core.Handler, middlewares ...core.Middleware) {
-	a.Handle(http.MethodDelete, path, handler, middlewares...)
+// URL builds the path of the route named with Route.WithName
+func (a *App) URL(name string, params map[string]string, query url.Values) (string, error) {
+	return core.BuildURL(a.routes, name, params, query)
 }
 
-func (a *App) Patch(path string, handler core.Handler, middlewares ...core.Middleware) {
-	a.Handle(http.MethodPatch, path, handler, middlewares...)
+// SetURLSigner sets the signer used by SignedURL and the signedurl middleware
+func (a *App) SetURLSigner(signer *core.URLSigner) {
+	a.urlSigner = signer
+}
+
+// SignedURL builds the path of a named route signed to expire after ttl
+func (a *App) SignedURL(name string, params map[string]string, ttl time.Duration) (string, error) {
+	if a.urlSigner == nil {
+		return "", errors.New("no url signer set: call SetURLSigner first")
+	}
+	path, err := a.URL(name, params, nil)
+	if err != nil {
+		return "", err
+	}
+	return a.urlSigner.Sign(path, ttl, nil)
 }
 
 func (a *App) Services() *services.Container {
@@ -69,17 +161,36 @@ func (a *App) Services() *services.Container {
 }
 
 func (a *App) Start(addr string) error {
+	// Unmatched requests go through app-level middleware to the NotFound handler
+	// The chain is built once, so set NotFound and app-level middleware before starting
+	notFound := core.Apply(a.notFound, a.middlewares...)
+
 	// Register router handler with mux
 	a.mux.HandleFunc("/", func(res http.ResponseWriter, req *http.Request) {
 		ctx := NewContext(req, res, a.services)
 		
 		// Set app-level services in context for UseServices middleware
 		ctx.Set("_app_services", a.services)
+		core.SetAppValues(ctx, core.AppValues{
+			Codecs:     a.codecs,
+			JSONEngine: a.jsonEngine,
+			URLBuilder: a,
+			URLSigner:  a.urlSigner,
+		})
+		
+		// Apply form limits and remove temporary upload files once the request ends
+		if ctxImpl, ok := ctx.(*contextImpl); ok {
+			ctxImpl.SetFormOptions(a.formOptions)
+			defer ctxImpl.Cleanup()
+		}
 		
-		// Try to match route
-		handler, params := a.router.Match(req.Method, req.URL.Path)
+		// Try to match route on the escaped path, so an escaped "/" stays inside its parameter
+		handler, params := a.router.Match(req.Method, req.URL.EscapedPath())
 		if handler == nil {
-			http.NotFound(res, req)
+			resp, err := notFound(ctx)
+			if err := core.HandleResponse(ctx, resp, err); err != nil {
+				a.errorHandler(ctx, err)
+			}
 			return
 		}
 		
@@ -90,8 +201,8 @@ func (a *App) Start(addr string) error {
 		
 		// Execute handler - handler returns error (orchestrator already handled response sending)
 		if err := handler(ctx); err != nil {
-			// Error handling will be done by error middleware if present
-			http.Error(res, err.Error(), http.StatusInternalServerError)
+			// Errors no middleware handled go to the app's error handler
+			a.errorHandler(ctx, err)
 		}
 	})
 	
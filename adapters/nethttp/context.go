@@ -3,10 +3,10 @@ package nethttp
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 
 	"github.com/hemant-mann/lumora-go/core"
 	"github.com/hemant-mann/lumora-go/services"
@@ -20,11 +20,16 @@ type contextImpl struct {
 	values     map[string]any
 	statusCode int
 	services   *services.Container
+	form       *core.Form
 }
 
 // NewContext creates a new context from http.Request and http.ResponseWriter
 func NewContext(req *http.Request, res http.ResponseWriter, svcs *services.Container) core.Context {
-	return &contextImpl{
+	// Track writes so the error path knows whether the response has started
+	if _, ok := res.(*responseWriter); !ok {
+		res = &responseWriter{ResponseWriter: res}
+	}
+	c := &contextImpl{
 		req:        req,
 		res:        res,
 		params:     make(map[string]string),
@@ -33,6 +38,8 @@ func NewContext(req *http.Request, res http.ResponseWriter, svcs *services.Conta
 		statusCode: 200,
 		services:   svcs,
 	}
+	c.form = core.NewForm(c.Request)
+	return c
 }
 
 func (c *contextImpl) Request() *http.Request {
@@ -56,6 +63,14 @@ func (c *contextImpl) Param(name string) string {
 	return c.params[name]
 }
 
+func (c *contextImpl) Params() map[string]string {
+	params := make(map[string]string, len(c.params))
+	for name, value := range c.params {
+		params[name] = value
+	}
+	return params
+}
+
 func (c *contextImpl) Query(name string) string {
 	if val, ok := c.queryCache[name]; ok {
 		return val
@@ -78,11 +93,18 @@ func (c *contextImpl) Status(code int) {
 	c.res.WriteHeader(code)
 }
 
+func (c *contextImpl) Written() bool {
+	if w, ok := c.res.(*responseWriter); ok {
+		return w.written
+	}
+	return false
+}
+
 func (c *contextImpl) JSON(code int, data any) error {
 	// Set header BEFORE calling Status() (which calls WriteHeader())
 	c.SetHeader("Content-Type", "application/json")
 	c.Status(code)
-	encoder := json.NewEncoder(c.res)
+	encoder := core.GetJSONEngine(c).NewEncoder(c.res)
 	return encoder.Encode(data)
 }
 
@@ -95,8 +117,11 @@ func (c *contextImpl) String(code int, format string, values ...any) error {
 }
 
 func (c *contextImpl) BindJSON(dest any) error {
-	decoder := json.NewDecoder(c.req.Body)
-	return decoder.Decode(dest)
+	return core.BindJSON(c, dest)
+}
+
+func (c *contextImpl) Bind(dest any) error {
+	return core.Bind(c, dest)
 }
 
 func (c *contextImpl) Context() context.Context {
@@ -113,6 +138,7 @@ func (c *contextImpl) WithContext(ctx context.Context) core.Context {
 		values:     c.values,
 		statusCode: c.statusCode,
 		services:   c.services,
+		form:       c.form,
 	}
 }
 
@@ -156,8 +182,8 @@ func (c *contextImpl) SetParams(params map[string]string) {
 }
 
 func (c *contextImpl) RequestBody() ([]byte, error) {
-	// Read the request body
-	body, err := io.ReadAll(c.req.Body)
+	// Read the request body, up to the form options' MaxBodySize
+	body, err := c.form.ReadBody(c.req.Body)
 	if err != nil {
 		return nil, err
 	}
@@ -165,3 +191,74 @@ func (c *contextImpl) RequestBody() ([]byte, error) {
 	c.req.Body = io.NopCloser(bytes.NewReader(body))
 	return body, nil
 }
+
+func (c *contextImpl) BodyReader() io.Reader {
+	return c.req.Body
+}
+
+func (c *contextImpl) FormValue(name string) string {
+	return c.form.Value(name)
+}
+
+func (c *contextImpl) FormValues() (url.Values, error) {
+	return c.form.Values()
+}
+
+func (c *contextImpl) PostFormValues() (url.Values, error) {
+	return c.form.BodyValues()
+}
+
+func (c *contextImpl) MultipartForm() (*core.MultipartForm, error) {
+	return c.form.Multipart()
+}
+
+func (c *contextImpl) FormFile(name string) (*core.FileHeader, error) {
+	return c.form.File(name)
+}
+
+// SetFormOptions sets the form parsing limits (used by app)
+func (c *contextImpl) SetFormOptions(options *core.FormOptions) {
+	c.form.SetOptions(options)
+}
+
+func (c *contextImpl) URL(name string, params map[string]string, query url.Values) (string, error) {
+	return core.ContextURL(c, name, params, query)
+}
+
+func (c *contextImpl) AbsoluteURL(name string, params map[string]string, query url.Values) (string, error) {
+	return core.ContextAbsoluteURL(c, name, params, query)
+}
+
+// Cleanup releases per-request resources such as temporary upload files
+func (c *contextImpl) Cleanup() {
+	c.form.RemoveAll()
+}
+
+// responseWriter records whether the status or body has been written
+type responseWriter struct {
+	http.ResponseWriter
+	written bool
+}
+
+func (w *responseWriter) WriteHeader(statusCode int) {
+	w.written = true
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.written = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush lets streaming responses flush through the wrapper
+func (w *responseWriter) Flush() {
+	w.written = true
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
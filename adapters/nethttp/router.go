@@ -1,6 +1,7 @@
 package nethttp
 
 import (
+	"net/url"
 	"strings"
 
 	"github.com/hemant-mann/lumora-go/core"
@@ -31,33 +32,74 @@ func (r *Router) Handle(method, pattern string, handler func(core.Context) error
 }
 
 func (r *Router) Match(method, path string) (func(core.Context) error, map[string]string) {
+	// Catch-all routes only win when no more specific route matches, the longest prefix first
+	var fallback *route
+	var fallbackParams map[string]string
+
 	for _, route := range r.routes {
 		if route.method != method {
 			continue
 		}
 		
 		params := matchPattern(route.pattern, path)
-		if params != nil {
+		if params == nil {
+			continue
+		}
+		if !isCatchAll(route.pattern) {
 			return route.handler, params
 		}
+		if fallback == nil || len(route.pattern) > len(fallback.pattern) {
+			fallback, fallbackParams = route, params
+		}
 	}
 	
+	if fallback != nil {
+		return fallback.handler, fallbackParams
+	}
 	return nil, nil
 }
 
-// matchPattern matches a pattern like "/users/:id" against a path like "/users/123"
-// Returns nil if no match, otherwise returns a map of parameter names to values
+// isCatchAll reports whether a pattern ends with a "*name" segment
+func isCatchAll(pattern string) bool {
+	lastSlash := strings.LastIndex(pattern, "/")
+	return strings.HasPrefix(pattern[lastSlash+1:], "*")
+}
+
+// matchPattern matches a pattern like "/users/:id" against an escaped path like "/users/123"
+// A trailing "*name" segment matches the rest of the path, e.g. "/static/*filepath"
+// Returns nil if no match, otherwise returns a map of parameter names to unescaped values
 func matchPattern(pattern, path string) map[string]string {
 	patternParts := strings.Split(strings.Trim(pattern, "/"), "/")
 	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+	for i, part := range pathParts {
+		if unescaped, err := url.PathUnescape(part); err == nil {
+			pathParts[i] = unescaped
+		}
+	}
 	
-	if len(patternParts) != len(pathParts) {
+	catchAll := isCatchAll(pattern)
+	if catchAll {
+		// The catch-all segment may match an empty remainder
+		if len(pathParts) < len(patternParts)-1 {
+			return nil
+		}
+	} else if len(patternParts) != len(pathParts) {
 		return nil
 	}
 	
 	params := make(map[string]string)
 	
 	for i, patternPart := range patternParts {
+		if catchAll && i == len(patternParts)-1 {
+			// It's a catch-all parameter, capture the remainder of the path
+			paramName := strings.TrimPrefix(patternPart, "*")
+			params[paramName] = strings.Join(pathParts[i:], "/")
+			if strings.HasSuffix(path, "/") && params[paramName] != "" {
+				params[paramName] += "/"
+			}
+			break
+		}
+
 		pathPart := pathParts[i]
 		
 		if strings.HasPrefix(patternPart, ":") {
@@ -71,5 +113,4 @@ func matchPattern(pattern, path string) map[string]string {
 	}
 	
 	return params
-}
-
+}
\ No newline at end of file
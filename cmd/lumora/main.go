@@ -0,0 +1,54 @@
+// Command lumora runs development helpers for lumora apps
+//
+//	lumora routes [package]
+//
+// routes prints the route table of the app in package (default "."), whose main must hand its
+// registration function to routes.Command
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+const usage = `usage: lumora <command> [arguments]
+
+commands:
+  routes [package]  print the route table of the app in package (default ".")
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "routes":
+		pkg := "."
+		if len(os.Args) > 2 {
+			pkg = os.Args[2]
+		}
+		os.Exit(run("go", "run", pkg, "routes"))
+	default:
+		fmt.Fprintf(os.Stderr, "lumora: unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+}
+
+// run runs a command with the standard streams attached and returns its exit code
+func run(name string, args ...string) int {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		if exit, ok := err.(*exec.ExitError); ok {
+			return exit.ExitCode()
+		}
+		fmt.Fprintln(os.Stderr, "lumora:", err)
+		return 1
+	}
+	return 0
+}
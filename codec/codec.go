@@ -0,0 +1,113 @@
+// Package codec provides additional body codecs for core.CodecRegistry
+//
+//	app.Codecs().Register(codec.MsgPack{})
+//	app.Codecs().Register(codec.CBOR{})
+//	app.Codecs().Register(codec.YAML{})
+//	app.Codecs().Register(codec.Protobuf{})
+//	app.Codecs().Register(codec.ProtoJSON{})
+package codec
+
+import (
+	"io"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/goccy/go-yaml"
+	"github.com/hemant-mann/lumora-go/core"
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// MsgPack encodes bodies as MessagePack
+type MsgPack struct{}
+
+func (MsgPack) ContentType() string { return "application/msgpack" }
+
+func (MsgPack) Encode(w io.Writer, v any) error { return msgpack.NewEncoder(w).Encode(v) }
+
+func (MsgPack) Decode(r io.Reader, v any) error { return msgpack.NewDecoder(r).Decode(v) }
+
+// CBOR encodes bodies as CBOR (RFC 8949)
+type CBOR struct{}
+
+func (CBOR) ContentType() string { return "application/cbor" }
+
+func (CBOR) Encode(w io.Writer, v any) error { return cbor.NewEncoder(w).Encode(v) }
+
+func (CBOR) Decode(r io.Reader, v any) error { return cbor.NewDecoder(r).Decode(v) }
+
+// YAML encodes bodies as YAML
+type YAML struct{}
+
+func (YAML) ContentType() string { return "application/yaml" }
+
+func (YAML) Encode(w io.Writer, v any) error { return yaml.NewEncoder(w).Encode(v) }
+
+func (YAML) Decode(r io.Reader, v any) error { return yaml.NewDecoder(r).Decode(v) }
+
+// Protobuf encodes proto.Message bodies in the protobuf binary format
+type Protobuf struct{}
+
+func (Protobuf) ContentType() string { return "application/x-protobuf" }
+
+func (Protobuf) Supports(v any) bool {
+	_, ok := v.(proto.Message)
+	return ok
+}
+
+func (Protobuf) Encode(w io.Writer, v any) error {
+	data, err := proto.Marshal(v.(proto.Message))
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+func (Protobuf) Decode(r io.Reader, v any) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	return proto.Unmarshal(data, v.(proto.Message))
+}
+
+// ProtoJSON encodes proto.Message bodies with the canonical protobuf JSON mapping
+// Register it after core.JSONCodec so it takes over JSON for messages only
+type ProtoJSON struct {
+	MarshalOptions   protojson.MarshalOptions
+	UnmarshalOptions protojson.UnmarshalOptions
+}
+
+func (ProtoJSON) ContentType() string { return "application/json" }
+
+func (ProtoJSON) Supports(v any) bool {
+	_, ok := v.(proto.Message)
+	return ok
+}
+
+func (c ProtoJSON) Encode(w io.Writer, v any) error {
+	data, err := c.MarshalOptions.Marshal(v.(proto.Message))
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+func (c ProtoJSON) Decode(r io.Reader, v any) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	return c.UnmarshalOptions.Unmarshal(data, v.(proto.Message))
+}
+
+// Interface checks
+var (
+	_ core.Codec          = MsgPack{}
+	_ core.Codec          = CBOR{}
+	_ core.Codec          = YAML{}
+	_ core.CodecSupporter = Protobuf{}
+	_ core.CodecSupporter = ProtoJSON{}
+)
@@ -1,6 +1,12 @@
 package core
 
-import "github.com/hemant-mann/lumora-go/services"
+import (
+	"io/fs"
+	"net/url"
+	"time"
+
+	"github.com/hemant-mann/lumora-go/services"
+)
 
 // App represents the main application interface
 type App interface {
@@ -8,22 +14,58 @@ type App interface {
 	Use(middleware ...Middleware)
 	
 	// Handle registers a handler for a specific method and path
-	Handle(method, path string, handler Handler, middlewares ...Middleware)
+	// The returned Route can be documented with its With* methods
+	Handle(method, path string, handler Handler, middlewares ...Middleware) *Route
 	
 	// Get registers a GET route
-	Get(path string, handler Handler, middlewares ...Middleware)
+	Get(path string, handler Handler, middlewares ...Middleware) *Route
 	
 	// Post registers a POST route
-	Post(path string, handler Handler, middlewares ...Middleware)
+	Post(path string, handler Handler, middlewares ...Middleware) *Route
 	
 	// Put registers a PUT route
-	Put(path string, handler Handler, middlewares ...Middleware)
+	Put(path string, handler Handler, middlewares ...Middleware) *Route
 	
 	// Delete registers a DELETE route
-	Delete(path string, handler Handler, middlewares ...Middleware)
+	Delete(path string, handler Handler, middlewares ...Middleware) *Route
 	
 	// Patch registers a PATCH route
-	Patch(path string, handler Handler, middlewares ...Middleware)
+	Patch(path string, handler Handler, middlewares ...Middleware) *Route
+	
+	// Routes returns the registered routes in registration order
+	Routes() []*Route
+	
+	// URL builds the path of the route named with Route.WithName, escaping params and appending query
+	URL(name string, params map[string]string, query url.Values) (string, error)
+	
+	// SetURLSigner sets the signer used by SignedURL and the signedurl middleware
+	SetURLSigner(signer *URLSigner)
+	
+	// SignedURL builds the path of a named route signed to expire after ttl
+	SignedURL(name string, params map[string]string, ttl time.Duration) (string, error)
+	
+	// Static serves files from fsys under the given path prefix
+	Static(prefix string, fsys fs.FS, options *StaticOptions)
+	
+	// NotFound sets the handler for requests that match no route
+	// App-level middleware runs for it like for any other route
+	// The chain is built when the app starts, so call it and Use before Start
+	NotFound(handler Handler)
+	
+	// SPA serves a single-page-app from fsys, falling back to its index for unknown paths
+	SPA(fsys fs.FS, options *SPAOptions) error
+	
+	// SetFormOptions sets the limits used when parsing request forms
+	SetFormOptions(options *FormOptions)
+	
+	// Codecs returns the codec registry used to negotiate response formats
+	Codecs() *CodecRegistry
+	
+	// SetJSONEngine sets the JSON implementation used for every JSON encode and decode
+	SetJSONEngine(engine JSONEngine)
+	
+	// SetErrorHandler sets the last-resort handler for errors left after the middleware chain
+	SetErrorHandler(handler ErrorHandler)
 	
 	// Start starts the server
 	Start(addr string) error
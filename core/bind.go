@@ -0,0 +1,234 @@
+package core
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Bind decodes the request body into dest based on the request Content-Type
+// Form and multipart bodies are bound to struct fields by their `form` tag, with multipart
+// files bound to *FileHeader and []*FileHeader fields; any other type is decoded by the
+// codec registered for it. Only body values are bound, never the query string
+// Unsupported types return 415 and malformed bodies 400
+// Every adapter binds through this function, so behavior is identical across adapters
+func Bind(ctx Context, dest any) error {
+	contentType := ctx.Header("Content-Type")
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return NewError(415, "Unsupported Content-Type")
+	}
+
+	switch mediaType {
+	case "application/x-www-form-urlencoded":
+		values, err := ctx.PostFormValues()
+		if err != nil {
+			return err
+		}
+		return bindFields(dest, "form", values, nil)
+	case "multipart/form-data":
+		form, err := ctx.MultipartForm()
+		if err != nil {
+			return err
+		}
+		return bindFields(dest, "form", form.Value, form.File)
+	}
+
+	codec := GetCodecs(ctx).Lookup(mediaType, dest)
+	if codec == nil {
+		return NewError(415, fmt.Sprintf("Unsupported Content-Type %q", mediaType))
+	}
+	return decodeBody(ctx, codec, dest)
+}
+
+// BindJSON decodes a JSON request body into dest regardless of the Content-Type
+func BindJSON(ctx Context, dest any) error {
+	codec := GetCodecs(ctx).Lookup("application/json", dest)
+	if codec == nil {
+		codec = JSONCodec{Engine: GetJSONEngine(ctx)}
+	}
+	return decodeBody(ctx, codec, dest)
+}
+
+// BindValues binds values to the fields of the struct dest points to, using `form` tags
+// Fields without a tag use their json name, then their Go name
+func BindValues(values url.Values, dest any) error {
+	return bindFields(dest, "form", values, nil)
+}
+
+func decodeBody(ctx Context, codec Codec, dest any) error {
+	if err := codec.Decode(ctx.BodyReader(), dest); err != nil {
+		if errors.Is(err, io.EOF) {
+			return NewError(400, "Request body is empty")
+		}
+		return WrapError(400, "Invalid request body", err)
+	}
+	return nil
+}
+
+var (
+	fileHeaderType      = reflect.TypeOf((*FileHeader)(nil))
+	fileHeaderSliceType = reflect.TypeOf([]*FileHeader(nil))
+	timeType            = reflect.TypeOf(time.Time{})
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// bindFields sets the fields of the struct dest points to from values and files,
+// naming fields by tag
+func bindFields(dest any, tag string, values url.Values, files map[string][]*FileHeader) error {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("bind: destination must be a non-nil pointer to a struct, got %T", dest)
+	}
+	return bindStruct(v.Elem(), tag, values, files)
+}
+
+func bindStruct(v reflect.Value, tag string, values url.Values, files map[string][]*FileHeader) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fieldValue := v.Field(i)
+
+		// Embedded structs contribute their fields to the parent
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && field.Tag.Get(tag) == "" {
+			if err := bindStruct(fieldValue, tag, values, files); err != nil {
+				return err
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		name := fieldName(field, tag)
+		if name == "" {
+			continue
+		}
+
+		switch field.Type {
+		case fileHeaderType:
+			if found := files[name]; len(found) > 0 {
+				fieldValue.Set(reflect.ValueOf(found[0]))
+			}
+			continue
+		case fileHeaderSliceType:
+			if found := files[name]; len(found) > 0 {
+				fieldValue.Set(reflect.ValueOf(found))
+			}
+			continue
+		}
+
+		found, ok := values[name]
+		if !ok || len(found) == 0 {
+			continue
+		}
+		if err := setField(fieldValue, found); err != nil {
+			return WrapError(400, fmt.Sprintf("Invalid value for %q", name), err)
+		}
+	}
+	return nil
+}
+
+// fieldName returns the binding name of a field, or "" if the field is skipped
+func fieldName(field reflect.StructField, tag string) string {
+	for _, key := range []string{tag, "json"} {
+		if value, ok := field.Tag.Lookup(key); ok {
+			name, _, _ := strings.Cut(value, ",")
+			if name == "-" {
+				return ""
+			}
+			if name != "" {
+				return name
+			}
+		}
+	}
+	return field.Name
+}
+
+// setField converts raw values to the field's type
+// Slices receive every value; any other type the first
+func setField(v reflect.Value, raw []string) error {
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8 && !v.Addr().Type().Implements(textUnmarshalerType) {
+		slice := reflect.MakeSlice(v.Type(), len(raw), len(raw))
+		for i, value := range raw {
+			if err := setValue(slice.Index(i), value); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+		return nil
+	}
+	return setValue(v, raw[0])
+}
+
+// setValue converts a single string to the type of v
+func setValue(v reflect.Value, raw string) error {
+	if v.Kind() == reflect.Pointer {
+		ptr := reflect.New(v.Type().Elem())
+		if err := setValue(ptr.Elem(), raw); err != nil {
+			return err
+		}
+		v.Set(ptr)
+		return nil
+	}
+	if v.CanAddr() && v.Addr().Type().Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw))
+	}
+	if v.Type() == timeType {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(parsed))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		v.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.Type() == reflect.TypeOf(time.Duration(0)) {
+			parsed, err := time.ParseDuration(raw)
+			if err != nil {
+				return err
+			}
+			v.SetInt(int64(parsed))
+			return nil
+		}
+		parsed, err := strconv.ParseInt(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(raw, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(parsed)
+	case reflect.Slice:
+		// []byte receives the raw value
+		v.SetBytes([]byte(raw))
+	default:
+		return fmt.Errorf("unsupported field type %s", v.Type())
+	}
+	return nil
+}
@@ -0,0 +1,286 @@
+package core
+
+import (
+	"encoding/xml"
+	"io"
+	"mime"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Codec encodes and decodes bodies for one media type
+type Codec interface {
+	// ContentType returns the media type handled by the codec, e.g. "application/json"
+	ContentType() string
+
+	// Encode writes v to w
+	Encode(w io.Writer, v any) error
+
+	// Decode reads r into v
+	Decode(r io.Reader, v any) error
+}
+
+// CodecSupporter is implemented by codecs that only handle some values,
+// e.g. a protobuf codec that only handles proto.Message
+type CodecSupporter interface {
+	Supports(v any) bool
+}
+
+// JSONCodec encodes bodies with a JSON engine, encoding/json when Engine is nil
+type JSONCodec struct {
+	Engine JSONEngine
+}
+
+func (JSONCodec) ContentType() string { return "application/json" }
+
+func (c JSONCodec) Encode(w io.Writer, v any) error { return c.engine().NewEncoder(w).Encode(v) }
+
+func (c JSONCodec) Decode(r io.Reader, v any) error { return c.engine().NewDecoder(r).Decode(v) }
+
+func (c JSONCodec) engine() JSONEngine {
+	if c.Engine == nil {
+		return StdJSON{}
+	}
+	return c.Engine
+}
+
+// XMLCodec encodes bodies with encoding/xml
+type XMLCodec struct{}
+
+func (XMLCodec) ContentType() string { return "application/xml" }
+
+func (XMLCodec) Encode(w io.Writer, v any) error { return xml.NewEncoder(w).Encode(v) }
+
+func (XMLCodec) Decode(r io.Reader, v any) error { return xml.NewDecoder(r).Decode(v) }
+
+// Supports reports false for maps, which encoding/xml cannot encode
+func (XMLCodec) Supports(v any) bool {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t != nil && t.Kind() != reflect.Map
+}
+
+// CodecRegistry holds the codecs an app can use for request and response bodies
+type CodecRegistry struct {
+	mu          sync.RWMutex
+	codecs      []Codec
+	defaultType string
+}
+
+// NewCodecRegistry creates a registry with JSON, the default, and XML
+func NewCodecRegistry() *CodecRegistry {
+	r := &CodecRegistry{defaultType: "application/json"}
+	r.Register(XMLCodec{})
+	r.Register(JSONCodec{})
+	return r
+}
+
+// defaultCodecs is used when a context carries no registry, e.g. in custom adapters
+var defaultCodecs = NewCodecRegistry()
+
+// Register adds a codec; a codec registered later takes precedence for the same media type
+func (r *CodecRegistry) Register(codec Codec) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.codecs = append([]Codec{codec}, r.codecs...)
+}
+
+// SetDefault sets the media type used when the client sends no Accept header
+// or accepts anything
+func (r *CodecRegistry) SetDefault(contentType string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.defaultType = normalizeMediaType(contentType)
+}
+
+// Lookup returns the codec for a media type that supports v, or nil
+// Structured syntax suffixes fall back to their base type, e.g. "application/problem+json" uses JSON
+func (r *CodecRegistry) Lookup(contentType string, v any) Codec {
+	mediaType := normalizeMediaType(contentType)
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if codec := r.find(mediaType, v); codec != nil {
+		return codec
+	}
+	if at := strings.LastIndex(mediaType, "+"); at >= 0 {
+		return r.find("application/"+mediaType[at+1:], v)
+	}
+	return nil
+}
+
+// Negotiate picks the codec for v that best matches an Accept header
+// It returns nil when the client accepts none of the registered media types
+func (r *CodecRegistry) Negotiate(accept string, v any) Codec {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// The default codec comes first so it wins ties such as "*/*"
+	var candidates []Codec
+	for _, codec := range r.codecs {
+		if normalizeMediaType(codec.ContentType()) == r.defaultType && supports(codec, v) {
+			candidates = append(candidates, codec)
+		}
+	}
+	for _, codec := range r.codecs {
+		if normalizeMediaType(codec.ContentType()) != r.defaultType && supports(codec, v) {
+			candidates = append(candidates, codec)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	if strings.TrimSpace(accept) == "" {
+		return candidates[0]
+	}
+
+	ranges := parseAccept(accept)
+	var best Codec
+	bestQ, bestOrder := 0.0, -1
+	for _, codec := range candidates {
+		q, order := matchAccept(ranges, normalizeMediaType(codec.ContentType()))
+		if q > bestQ || (q == bestQ && q > 0 && order < bestOrder) {
+			best, bestQ, bestOrder = codec, q, order
+		}
+	}
+	return best
+}
+
+// NegotiateMediaType returns the offer that best matches an Accept header, or "" if none is acceptable
+// Earlier offers win ties, and an empty Accept header selects the first offer
+func NegotiateMediaType(accept string, offers ...string) string {
+	if len(offers) == 0 {
+		return ""
+	}
+	if strings.TrimSpace(accept) == "" {
+		return offers[0]
+	}
+	ranges := parseAccept(accept)
+	best, bestQ, bestOrder := "", 0.0, -1
+	for _, offer := range offers {
+		q, order := matchAccept(ranges, normalizeMediaType(offer))
+		if q > bestQ || (q == bestQ && q > 0 && order < bestOrder) {
+			best, bestQ, bestOrder = offer, q, order
+		}
+	}
+	return best
+}
+
+// SetJSONEngine switches the registered JSON codecs to engine, keeping their precedence
+func (r *CodecRegistry) SetJSONEngine(engine JSONEngine) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, codec := range r.codecs {
+		if _, ok := codec.(JSONCodec); ok {
+			r.codecs[i] = JSONCodec{Engine: engine}
+		}
+	}
+}
+
+// ContentTypes returns the registered media types, most preferred first
+func (r *CodecRegistry) ContentTypes() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	seen := make(map[string]bool)
+	var types []string
+	for _, codec := range r.codecs {
+		if mediaType := codec.ContentType(); !seen[mediaType] {
+			seen[mediaType] = true
+			types = append(types, mediaType)
+		}
+	}
+	return types
+}
+
+func (r *CodecRegistry) find(mediaType string, v any) Codec {
+	for _, codec := range r.codecs {
+		if normalizeMediaType(codec.ContentType()) == mediaType && supports(codec, v) {
+			return codec
+		}
+	}
+	return nil
+}
+
+// GetCodecs returns the app's codec registry for a request
+func GetCodecs(ctx Context) *CodecRegistry {
+	if registry, ok := GetValue(ctx, codecsKey); ok {
+		return registry
+	}
+	return defaultCodecs
+}
+
+func supports(codec Codec, v any) bool {
+	if supporter, ok := codec.(CodecSupporter); ok {
+		return supporter.Supports(v)
+	}
+	return true
+}
+
+// normalizeMediaType strips parameters and lowercases a media type
+func normalizeMediaType(contentType string) string {
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		return mediaType
+	}
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	return strings.ToLower(strings.TrimSpace(mediaType))
+}
+
+// acceptRange is one media range of an Accept header
+type acceptRange struct {
+	mediaType string
+	q         float64
+	order     int
+}
+
+// parseAccept parses an Accept header into media ranges
+func parseAccept(accept string) []acceptRange {
+	var ranges []acceptRange
+	for i, part := range strings.Split(accept, ",") {
+		mediaType, params, _ := strings.Cut(part, ";")
+		mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+		if mediaType == "" {
+			continue
+		}
+		if mediaType == "*" {
+			mediaType = "*/*"
+		}
+		q := 1.0
+		for _, param := range strings.Split(params, ";") {
+			name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if strings.EqualFold(name, "q") {
+				if parsed, err := strconv.ParseFloat(value, 64); err == nil && parsed >= 0 && parsed <= 1 {
+					q = parsed
+				}
+			}
+		}
+		ranges = append(ranges, acceptRange{mediaType: mediaType, q: q, order: i})
+	}
+	return ranges
+}
+
+// matchAccept returns the quality of the most specific range matching mediaType and that range's position
+func matchAccept(ranges []acceptRange, mediaType string) (float64, int) {
+	mainType, _, _ := strings.Cut(mediaType, "/")
+	q, order, specificity := 0.0, len(ranges), -1
+	for _, r := range ranges {
+		var s int
+		switch {
+		case r.mediaType == mediaType:
+			s = 2
+		case r.mediaType == mainType+"/*":
+			s = 1
+		case r.mediaType == "*/*":
+			s = 0
+		default:
+			continue
+		}
+		if s > specificity {
+			q, order, specificity = r.q, r.order, s
+		}
+	}
+	return q, order
+}
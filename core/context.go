@@ -2,7 +2,9 @@ package core
 
 import (
 	"context"
+	"io"
 	"net/http"
+	"net/url"
 )
 
 // Context represents the request context with framework-agnostic abstractions
@@ -22,6 +24,9 @@ type Context interface {
 	// Param returns a path parameter by name
 	Param(name string) string
 
+	// Params returns all path parameters of the matched route
+	Params() map[string]string
+
 	// Query returns a query parameter by name
 	Query(name string) string
 
@@ -34,6 +39,9 @@ type Context interface {
 	// Status sets the HTTP status code
 	Status(code int)
 
+	// Written reports whether the response status or body has already been sent
+	Written() bool
+
 	// JSON sends a JSON response
 	JSON(code int, data any) error
 
@@ -43,6 +51,10 @@ type Context interface {
 	// BindJSON binds the request body to a struct
 	BindJSON(dest any) error
 
+	// Bind decodes the request body into dest using the codec for its Content-Type
+	// Form and multipart bodies bind to struct fields by `form` tag
+	Bind(dest any) error
+
 	// Context returns the underlying context.Context
 	Context() context.Context
 
@@ -56,5 +68,31 @@ type Context interface {
 	MustService(name string) any
 
 	// RequestBody returns the raw request body as bytes
+	// Bodies larger than the app's FormOptions.MaxBodySize fail with 413
 	RequestBody() ([]byte, error)
+
+	// BodyReader returns the request body as a stream without buffering it
+	// The body can only be consumed once, either through BodyReader or RequestBody
+	BodyReader() io.Reader
+
+	// FormValue returns the first form value for name, from the body or the query string
+	FormValue(name string) string
+
+	// FormValues returns the parsed URL-encoded or multipart body values followed by the query values
+	FormValues() (url.Values, error)
+
+	// PostFormValues returns the parsed URL-encoded or multipart body values without the query values
+	PostFormValues() (url.Values, error)
+
+	// MultipartForm returns the parsed multipart form, including uploaded files
+	MultipartForm() (*MultipartForm, error)
+
+	// FormFile returns the first file uploaded under name
+	FormFile(name string) (*FileHeader, error)
+
+	// URL builds the path of a named route, like App.URL
+	URL(name string, params map[string]string, query url.Values) (string, error)
+
+	// AbsoluteURL builds the URL of a named route with the request's scheme and host
+	AbsoluteURL(name string, params map[string]string, query url.Values) (string, error)
 }
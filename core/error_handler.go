@@ -0,0 +1,26 @@
+package core
+
+// ErrorHandler renders an error that no middleware turned into a response
+// Every adapter calls it after the middleware chain, so unhandled errors behave the same everywhere
+type ErrorHandler func(ctx Context, err error)
+
+// DefaultErrorHandler responds with the error's status code and public message
+// It writes nothing if the response has already been sent
+func DefaultErrorHandler(ctx Context, err error) {
+	if ctx.Written() {
+		return
+	}
+	status, message := PublicError(err)
+	resp := NewResponse().
+		WithStatus(status).
+		WithBody(map[string]string{"error": message})
+	if httpErr := GetHTTPError(err); httpErr != nil {
+		for name, value := range httpErr.Headers {
+			resp.WithHeader(name, value)
+		}
+	}
+	if sendErr := resp.Send(ctx); sendErr != nil && !ctx.Written() {
+		// The error body could not be encoded; fall back to plain text
+		ctx.String(status, "%s", message)
+	}
+}
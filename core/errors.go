@@ -1,19 +1,160 @@
 package core
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"runtime"
+)
 
 // Error represents an HTTP error
+// Message is the public message sent to clients; Err is the internal cause, kept for logs and errors.Is
+// The problem fields are optional RFC 9457 members used by errorhandler's problem details mode
 type Error struct {
 	Code    int
 	Message string
 	Err     error
+
+	// ErrorCode is a machine-readable code clients can switch on, e.g. "order_not_found"
+	ErrorCode string
+	// Details are additional public fields describing the error
+	// Problem details render them as extension members
+	Details map[string]any
+	// Headers are set on the error response, e.g. Retry-After
+	Headers map[string]string
+
+	// Type is a URI identifying the problem type; "about:blank" when empty
+	Type string
+	// Title is a short summary of the problem type; the status text when empty
+	Title string
+	// Detail explains this occurrence of the problem; Message when empty
+	Detail string
+	// Instance is a URI identifying this occurrence; the request path when empty
+	Instance string
+
+	// stack holds the program counters of the call that created the error
+	stack []uintptr
 }
 
+// Error returns the public message followed by the internal cause, for logs
+// Use PublicMessage for text that is safe to send to clients
 func (e *Error) Error() string {
+	message := e.PublicMessage()
 	if e.Err != nil {
-		return e.Err.Error()
+		return message + ": " + e.Err.Error()
+	}
+	return message
+}
+
+// Unwrap returns the internal cause so errors.Is and errors.As see through WrapError
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is an *Error with the same status code
+// A target with an ErrorCode also has to match it, so errors.Is(err, core.ErrNotFound) matches any 404
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	return e.Code == t.Code && (t.ErrorCode == "" || e.ErrorCode == t.ErrorCode)
+}
+
+// PublicMessage returns the message that is safe to send to clients
+func (e *Error) PublicMessage() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	if text := http.StatusText(e.Code); text != "" {
+		return text
+	}
+	return "Error"
+}
+
+// StackTrace returns the call stack where the error was created, one "function file:line" per frame
+// Copies made with the With* methods record where they were made, so the shared errors below
+// report the handler that customized them rather than package initialization
+func (e *Error) StackTrace() []string {
+	if len(e.stack) == 0 {
+		return nil
+	}
+	var trace []string
+	frames := runtime.CallersFrames(e.stack)
+	for {
+		frame, more := frames.Next()
+		trace = append(trace, fmt.Sprintf("%s %s:%d", frame.Function, frame.File, frame.Line))
+		if !more {
+			return trace
+		}
+	}
+}
+
+// callers records the stack, skipping skip frames above the function that called it
+func callers(skip int) []uintptr {
+	pcs := make([]uintptr, 32)
+	// Also skip runtime.Callers, callers and its caller
+	n := runtime.Callers(skip+3, pcs)
+	return pcs[:n]
+}
+
+// clone returns a copy of e whose maps can be changed without affecting e
+// The copy records the stack of the With* call that made it
+func (e *Error) clone() *Error {
+	c := *e
+	c.Details = maps.Clone(e.Details)
+	c.Headers = maps.Clone(e.Headers)
+	c.stack = callers(1)
+	return &c
+}
+
+// WithMessage returns a copy of the error with a different public message
+func (e *Error) WithMessage(message string) *Error {
+	c := e.clone()
+	c.Message = message
+	return c
+}
+
+// WithErr returns a copy of the error wrapping err as its internal cause
+func (e *Error) WithErr(err error) *Error {
+	c := e.clone()
+	c.Err = err
+	return c
+}
+
+// WithErrorCode returns a copy of the error with a machine-readable code
+func (e *Error) WithErrorCode(code string) *Error {
+	c := e.clone()
+	c.ErrorCode = code
+	return c
+}
+
+// WithDetail returns a copy of the error with a detail field added
+func (e *Error) WithDetail(name string, value any) *Error {
+	c := e.clone()
+	if c.Details == nil {
+		c.Details = make(map[string]any)
 	}
-	return e.Message
+	c.Details[name] = value
+	return c
+}
+
+// WithHeader returns a copy of the error with a response header added
+func (e *Error) WithHeader(name, value string) *Error {
+	c := e.clone()
+	if c.Headers == nil {
+		c.Headers = make(map[string]string)
+	}
+	c.Headers[name] = value
+	return c
+}
+
+// WithType returns a copy of the error with a problem type URI
+func (e *Error) WithType(problemType string) *Error {
+	c := e.clone()
+	c.Type = problemType
+	return c
 }
 
 // NewError creates a new HTTP error
@@ -21,6 +162,7 @@ func NewError(code int, message string) *Error {
 	return &Error{
 		Code:    code,
 		Message: message,
+		stack:   callers(0),
 	}
 }
 
@@ -30,10 +172,12 @@ func WrapError(code int, message string, err error) *Error {
 		Code:    code,
 		Message: message,
 		Err:     err,
+		stack:   callers(0),
 	}
 }
 
 // Common HTTP errors
+// These are shared values: use the With* methods, which return copies, to customize them
 var (
 	ErrNotFound            = NewError(404, "Not Found")
 	ErrBadRequest          = NewError(400, "Bad Request")
@@ -42,6 +186,112 @@ var (
 	ErrInternalServerError = NewError(500, "Internal Server Error")
 )
 
+// BadRequest creates a 400 error; an empty message uses the status text
+func BadRequest(message string) *Error {
+	return NewError(http.StatusBadRequest, message)
+}
+
+// Unauthorized creates a 401 error; an empty message uses the status text
+func Unauthorized(message string) *Error {
+	return NewError(http.StatusUnauthorized, message)
+}
+
+// PaymentRequired creates a 402 error; an empty message uses the status text
+func PaymentRequired(message string) *Error {
+	return NewError(http.StatusPaymentRequired, message)
+}
+
+// Forbidden creates a 403 error; an empty message uses the status text
+func Forbidden(message string) *Error {
+	return NewError(http.StatusForbidden, message)
+}
+
+// NotFound creates a 404 error; an empty message uses the status text
+func NotFound(message string) *Error {
+	return NewError(http.StatusNotFound, message)
+}
+
+// MethodNotAllowed creates a 405 error; an empty message uses the status text
+func MethodNotAllowed(message string) *Error {
+	return NewError(http.StatusMethodNotAllowed, message)
+}
+
+// NotAcceptable creates a 406 error; an empty message uses the status text
+func NotAcceptable(message string) *Error {
+	return NewError(http.StatusNotAcceptable, message)
+}
+
+// RequestTimeout creates a 408 error; an empty message uses the status text
+func RequestTimeout(message string) *Error {
+	return NewError(http.StatusRequestTimeout, message)
+}
+
+// Conflict creates a 409 error; an empty message uses the status text
+func Conflict(message string) *Error {
+	return NewError(http.StatusConflict, message)
+}
+
+// Gone creates a 410 error; an empty message uses the status text
+func Gone(message string) *Error {
+	return NewError(http.StatusGone, message)
+}
+
+// PreconditionFailed creates a 412 error; an empty message uses the status text
+func PreconditionFailed(message string) *Error {
+	return NewError(http.StatusPreconditionFailed, message)
+}
+
+// PayloadTooLarge creates a 413 error; an empty message uses the status text
+func PayloadTooLarge(message string) *Error {
+	return NewError(http.StatusRequestEntityTooLarge, message)
+}
+
+// UnsupportedMediaType creates a 415 error; an empty message uses the status text
+func UnsupportedMediaType(message string) *Error {
+	return NewError(http.StatusUnsupportedMediaType, message)
+}
+
+// UnprocessableEntity creates a 422 error; an empty message uses the status text
+func UnprocessableEntity(message string) *Error {
+	return NewError(http.StatusUnprocessableEntity, message)
+}
+
+// Locked creates a 423 error; an empty message uses the status text
+func Locked(message string) *Error {
+	return NewError(http.StatusLocked, message)
+}
+
+// TooManyRequests creates a 429 error; an empty message uses the status text
+func TooManyRequests(message string) *Error {
+	return NewError(http.StatusTooManyRequests, message)
+}
+
+// InternalServerError creates a 500 error; an empty message uses the status text
+// Attach the internal cause with WithErr; it is logged but never sent to clients
+func InternalServerError(message string) *Error {
+	return NewError(http.StatusInternalServerError, message)
+}
+
+// NotImplemented creates a 501 error; an empty message uses the status text
+func NotImplemented(message string) *Error {
+	return NewError(http.StatusNotImplemented, message)
+}
+
+// BadGateway creates a 502 error; an empty message uses the status text
+func BadGateway(message string) *Error {
+	return NewError(http.StatusBadGateway, message)
+}
+
+// ServiceUnavailable creates a 503 error; an empty message uses the status text
+func ServiceUnavailable(message string) *Error {
+	return NewError(http.StatusServiceUnavailable, message)
+}
+
+// GatewayTimeout creates a 504 error; an empty message uses the status text
+func GatewayTimeout(message string) *Error {
+	return NewError(http.StatusGatewayTimeout, message)
+}
+
 // IsHTTPError checks if an error is an HTTP error
 func IsHTTPError(err error) bool {
 	var httpErr *Error
@@ -57,3 +307,14 @@ func GetHTTPError(err error) *Error {
 	return nil
 }
 
+// PublicError returns the status code and client-safe message for err
+// Errors that are not HTTP errors become a 500 without their internal text
+func PublicError(err error) (int, string) {
+	if GetValidationError(err) != nil {
+		return http.StatusBadRequest, "Validation failed"
+	}
+	if httpErr := GetHTTPError(err); httpErr != nil {
+		return httpErr.Code, httpErr.PublicMessage()
+	}
+	return http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError)
+}
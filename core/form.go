@@ -0,0 +1,376 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"os"
+	"sync"
+)
+
+// FormOptions represents form parsing configuration options
+type FormOptions struct {
+	// MaxMemory is the number of bytes of file content kept in memory per request
+	// Larger uploads are streamed to temporary files
+	MaxMemory int64
+	// MaxFileSize limits the size of each uploaded file; zero means no limit
+	MaxFileSize int64
+	// MaxBodySize limits the total size of the request body; zero means no limit
+	MaxBodySize int64
+	// TempDir is the directory for temporary upload files; empty uses os.TempDir()
+	TempDir string
+}
+
+// DefaultFormOptions returns default form parsing options
+func DefaultFormOptions() *FormOptions {
+	return &FormOptions{
+		MaxMemory:   32 << 20,
+		MaxFileSize: 0,
+		MaxBodySize: 64 << 20,
+	}
+}
+
+// FileHeader describes a file uploaded in a multipart form
+type FileHeader struct {
+	Filename string
+	Header   textproto.MIMEHeader
+	Size     int64
+
+	content []byte
+	tmpfile string
+}
+
+// Open opens the uploaded file for reading
+func (f *FileHeader) Open() (multipart.File, error) {
+	if f.tmpfile != "" {
+		return os.Open(f.tmpfile)
+	}
+	return sectionReadCloser{io.NewSectionReader(bytes.NewReader(f.content), 0, int64(len(f.content)))}, nil
+}
+
+// MultipartForm is a parsed multipart form
+// Value holds the text fields and File the uploaded files, both keyed by field name
+type MultipartForm struct {
+	Value map[string][]string
+	File  map[string][]*FileHeader
+}
+
+// RemoveAll removes the temporary files backing the form's uploads
+func (f *MultipartForm) RemoveAll() error {
+	var firstErr error
+	for _, files := range f.File {
+		for _, file := range files {
+			if file.tmpfile == "" {
+				continue
+			}
+			if err := os.Remove(file.tmpfile); err != nil && !errors.Is(err, os.ErrNotExist) && firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+// maxFormValueBytes limits the combined size of the text fields in a multipart form
+const maxFormValueBytes = 10 << 20
+
+var (
+	// errBodyTooLarge is returned by the body reader once MaxBodySize is exceeded
+	errBodyTooLarge = errors.New("request body too large")
+)
+
+// Form lazily parses and caches the form data of a single request
+// Adapters create one per request so every adapter parses forms identically
+type Form struct {
+	request func() *http.Request
+	options *FormOptions
+
+	once      sync.Once
+	body      url.Values
+	values    url.Values
+	multipart *MultipartForm
+	err       error
+}
+
+// NewForm creates a form for a request
+// request is only called on first access, so requests that never read the form pay nothing
+func NewForm(request func() *http.Request) *Form {
+	return &Form{request: request}
+}
+
+// SetOptions sets the limits used when the form is parsed
+func (f *Form) SetOptions(options *FormOptions) {
+	f.options = options
+}
+
+// Values returns the body form values followed by the query values
+func (f *Form) Values() (url.Values, error) {
+	f.once.Do(f.parse)
+	return f.values, f.err
+}
+
+// BodyValues returns the body form values without the query values
+func (f *Form) BodyValues() (url.Values, error) {
+	f.once.Do(f.parse)
+	return f.body, f.err
+}
+
+// Value returns the first value for name, or an empty string
+func (f *Form) Value(name string) string {
+	values, _ := f.Values()
+	return values.Get(name)
+}
+
+// Multipart returns the parsed multipart form
+func (f *Form) Multipart() (*MultipartForm, error) {
+	f.once.Do(f.parse)
+	if f.err != nil {
+		return nil, f.err
+	}
+	if f.multipart == nil {
+		return nil, NewError(415, "Content-Type is not multipart/form-data")
+	}
+	return f.multipart, nil
+}
+
+// File returns the first file uploaded under name
+func (f *Form) File(name string) (*FileHeader, error) {
+	form, err := f.Multipart()
+	if err != nil {
+		return nil, err
+	}
+	if files := form.File[name]; len(files) > 0 {
+		return files[0], nil
+	}
+	return nil, NewError(400, fmt.Sprintf("Missing form file %q", name))
+}
+
+// ReadBody reads a whole request body, failing with 413 once it exceeds MaxBodySize
+// Adapters read Context.RequestBody through it so buffered bodies obey the same limit as forms
+func (f *Form) ReadBody(body io.Reader) ([]byte, error) {
+	options := f.options
+	if options == nil {
+		options = DefaultFormOptions()
+	}
+	if options.MaxBodySize > 0 {
+		body = &limitedBodyReader{r: body, remaining: options.MaxBodySize}
+	}
+	data, err := io.ReadAll(body)
+	if errors.Is(err, errBodyTooLarge) {
+		return nil, NewError(413, "Request body too large")
+	}
+	return data, err
+}
+
+// RemoveAll removes temporary upload files; adapters call it when the request ends
+func (f *Form) RemoveAll() error {
+	if f.multipart == nil {
+		return nil
+	}
+	return f.multipart.RemoveAll()
+}
+
+func (f *Form) parse() {
+	options := f.options
+	if options == nil {
+		options = DefaultFormOptions()
+	}
+	req := f.request()
+
+	f.body = make(url.Values)
+	mediaType, params, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if req.Body != nil {
+		body := io.Reader(req.Body)
+		if options.MaxBodySize > 0 {
+			body = &limitedBodyReader{r: body, remaining: options.MaxBodySize}
+		}
+
+		switch mediaType {
+		case "application/x-www-form-urlencoded":
+			f.err = f.parseURLEncoded(body)
+		case "multipart/form-data":
+			f.err = f.parseMultipart(body, params["boundary"], options)
+		}
+	}
+
+	// Query values come after body values, so FormValue prefers the body
+	f.values = make(url.Values, len(f.body))
+	for name, values := range f.body {
+		f.values[name] = append([]string(nil), values...)
+	}
+	if query, err := url.ParseQuery(req.URL.RawQuery); err == nil {
+		for name, values := range query {
+			f.values[name] = append(f.values[name], values...)
+		}
+	}
+}
+
+func (f *Form) parseURLEncoded(body io.Reader) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return formReadError(err)
+	}
+	values, err := url.ParseQuery(string(data))
+	if err != nil {
+		return WrapError(400, "Malformed form body", err)
+	}
+	for name, v := range values {
+		f.body[name] = append(f.body[name], v...)
+	}
+	return nil
+}
+
+func (f *Form) parseMultipart(body io.Reader, boundary string, options *FormOptions) error {
+	if boundary == "" {
+		return NewError(400, "Missing multipart boundary")
+	}
+
+	form := &MultipartForm{
+		Value: make(map[string][]string),
+		File:  make(map[string][]*FileHeader),
+	}
+	f.multipart = form
+
+	memoryLeft := options.MaxMemory
+	valuesLeft := int64(maxFormValueBytes)
+	reader := multipart.NewReader(body, boundary)
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return formReadError(err)
+		}
+
+		name := part.FormName()
+		if name == "" {
+			part.Close()
+			continue
+		}
+
+		if part.FileName() == "" {
+			// Text fields always stay in memory
+			var buf bytes.Buffer
+			n, err := io.Copy(&buf, io.LimitReader(part, valuesLeft+1))
+			part.Close()
+			if err != nil {
+				return formReadError(err)
+			}
+			if n > valuesLeft {
+				return NewError(413, "Form values too large")
+			}
+			valuesLeft -= n
+			form.Value[name] = append(form.Value[name], buf.String())
+			f.body[name] = append(f.body[name], buf.String())
+			continue
+		}
+
+		file, err := readFormFile(part, &memoryLeft, options)
+		part.Close()
+		if file != nil {
+			form.File[name] = append(form.File[name], file)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readFormFile reads an uploaded file, keeping it in memory while memoryLeft allows
+// and spilling to a temporary file otherwise
+func readFormFile(part *multipart.Part, memoryLeft *int64, options *FormOptions) (*FileHeader, error) {
+	file := &FileHeader{
+		Filename: part.FileName(),
+		Header:   part.Header,
+	}
+
+	src := io.Reader(part)
+	if options.MaxFileSize > 0 {
+		src = io.LimitReader(part, options.MaxFileSize+1)
+	}
+
+	var buf bytes.Buffer
+	n, err := io.Copy(&buf, io.LimitReader(src, *memoryLeft+1))
+	if err != nil {
+		return nil, formReadError(err)
+	}
+	if n <= *memoryLeft {
+		*memoryLeft -= n
+		file.content = buf.Bytes()
+		file.Size = n
+		if options.MaxFileSize > 0 && file.Size > options.MaxFileSize {
+			return nil, fileTooLarge(file.Filename)
+		}
+		return file, nil
+	}
+
+	// Over the memory threshold: stream the rest to disk
+	tmp, err := os.CreateTemp(options.TempDir, "lumora-upload-*")
+	if err != nil {
+		return nil, WrapError(500, "Failed to store upload", err)
+	}
+	file.tmpfile = tmp.Name()
+	size, err := io.Copy(tmp, io.MultiReader(&buf, src))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	file.Size = size
+	if err != nil {
+		// Return the file so the caller tracks and removes the temporary file
+		return file, formReadError(err)
+	}
+	if options.MaxFileSize > 0 && file.Size > options.MaxFileSize {
+		return file, fileTooLarge(file.Filename)
+	}
+	return file, nil
+}
+
+func fileTooLarge(filename string) error {
+	return NewError(413, fmt.Sprintf("File %q is too large", filename))
+}
+
+// formReadError converts errors from reading the body into HTTP errors
+func formReadError(err error) error {
+	if errors.Is(err, errBodyTooLarge) {
+		return NewError(413, "Request body too large")
+	}
+	return WrapError(400, "Malformed form body", err)
+}
+
+// limitedBodyReader fails with errBodyTooLarge once more than remaining bytes are read
+type limitedBodyReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (l *limitedBodyReader) Read(p []byte) (int, error) {
+	if l.remaining < 0 {
+		return 0, errBodyTooLarge
+	}
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n, errBodyTooLarge
+	}
+	return n, err
+}
+
+// sectionReadCloser adapts an in-memory upload to multipart.File
+type sectionReadCloser struct {
+	*io.SectionReader
+}
+
+func (sectionReadCloser) Close() error {
+	return nil
+}
@@ -18,7 +18,19 @@ func Compose(middlewares ...Middleware) Middleware {
 }
 
 // Apply applies middleware to a handler
+// It waits for any route registration in progress, so hooks it applies never describe that route
 func Apply(handler Handler, middlewares ...Middleware) Handler {
+	describeMu.Lock()
+	defer describeMu.Unlock()
 	return Compose(middlewares...)(handler)
 }
 
+
+// NotFoundHandler is the default handler for requests that match no route
+// It responds with a JSON 404 in the same shape as the default error handler
+func NotFoundHandler(ctx Context) (*Response, error) {
+	resp := NewResponse().
+		WithStatus(404).
+		WithBody(map[string]string{"error": "Not Found"})
+	return resp, nil
+}
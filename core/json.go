@@ -0,0 +1,76 @@
+package core
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// JSONEngine encodes and decodes JSON
+// Set one on the app with App.SetJSONEngine to replace encoding/json everywhere:
+// Response.Send, ctx.JSON, ctx.Bind, ctx.BindJSON and the body hooks
+type JSONEngine interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+	NewEncoder(w io.Writer) JSONEncoder
+	NewDecoder(r io.Reader) JSONDecoder
+}
+
+// JSONEncoder writes JSON values to a stream
+type JSONEncoder interface {
+	Encode(v any) error
+}
+
+// JSONDecoder reads JSON values from a stream
+type JSONDecoder interface {
+	Decode(v any) error
+}
+
+// StdJSON is the encoding/json engine, used unless the app sets another
+type StdJSON struct{}
+
+func (StdJSON) Marshal(v any) ([]byte, error) { return json.Marshal(v) }
+
+func (StdJSON) Unmarshal(data []byte, v any) error {
+	return WrapJSONDecodeError(json.Unmarshal(data, v))
+}
+
+func (StdJSON) NewEncoder(w io.Writer) JSONEncoder { return json.NewEncoder(w) }
+
+func (StdJSON) NewDecoder(r io.Reader) JSONDecoder { return WrapJSONDecoder(json.NewDecoder(r)) }
+
+// JSONDecodeError wraps a failure to decode JSON, so it can be recognized whichever engine decoded it
+// Engines return it from Unmarshal and Decode; the underlying error stays available to errors.As
+type JSONDecodeError struct {
+	Err error
+}
+
+func (e *JSONDecodeError) Error() string { return e.Err.Error() }
+
+func (e *JSONDecodeError) Unwrap() error { return e.Err }
+
+// WrapJSONDecodeError wraps err in a JSONDecodeError; nil stays nil
+func WrapJSONDecodeError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &JSONDecodeError{Err: err}
+}
+
+// WrapJSONDecoder wraps the errors of decoder in JSONDecodeError
+func WrapJSONDecoder(decoder JSONDecoder) JSONDecoder {
+	return jsonDecoder{decoder}
+}
+
+type jsonDecoder struct {
+	decoder JSONDecoder
+}
+
+func (d jsonDecoder) Decode(v any) error { return WrapJSONDecodeError(d.decoder.Decode(v)) }
+
+// GetJSONEngine returns the app's JSON engine for a request
+func GetJSONEngine(ctx Context) JSONEngine {
+	if engine, ok := GetValue(ctx, jsonEngineKey); ok {
+		return engine
+	}
+	return StdJSON{}
+}
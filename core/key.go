@@ -0,0 +1,65 @@
+package core
+
+// Key is a typed context key
+// Values stored with SetValue are read back with GetValue as T, without type assertions
+//
+//	var currentUser = core.NewKey[*User]("currentUser")
+//
+//	core.SetValue(ctx, currentUser, user)
+//	user, ok := core.GetValue(ctx, currentUser)
+type Key[T any] struct {
+	name string
+}
+
+// NewKey creates a typed context key stored under name
+// Keys with the same name share the value, so a typed key can read values set with ctx.Set
+func NewKey[T any](name string) Key[T] {
+	return Key[T]{name: name}
+}
+
+// Name returns the context key the value is stored under
+func (k Key[T]) Name() string {
+	return k.name
+}
+
+// GetValue retrieves the value stored under key
+// It returns false if nothing is stored or the stored value is not a T
+func GetValue[T any](ctx Context, key Key[T]) (T, bool) {
+	if value, ok := ctx.Get(key.name); ok {
+		if typed, ok := value.(T); ok {
+			return typed, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// SetValue stores value under key
+func SetValue[T any](ctx Context, key Key[T], value T) {
+	ctx.Set(key.name, value)
+}
+
+// Keys of the values every adapter stores in the request context
+var (
+	codecsKey     = NewKey[*CodecRegistry]("_codecs")
+	jsonEngineKey = NewKey[JSONEngine]("_json_engine")
+	urlBuilderKey = NewKey[URLBuilder]("_url_builder")
+	urlSignerKey  = NewKey[*URLSigner]("_url_signer")
+)
+
+// AppValues are the app-wide values an adapter stores in each request context
+type AppValues struct {
+	Codecs     *CodecRegistry
+	JSONEngine JSONEngine
+	URLBuilder URLBuilder
+	URLSigner  *URLSigner
+}
+
+// SetAppValues stores values in ctx, where GetCodecs, GetJSONEngine, ContextURL and GetURLSigner read them
+// Adapters call it for every request so the keys stay private to core
+func SetAppValues(ctx Context, values AppValues) {
+	SetValue(ctx, codecsKey, values.Codecs)
+	SetValue(ctx, jsonEngineKey, values.JSONEngine)
+	SetValue(ctx, urlBuilderKey, values.URLBuilder)
+	SetValue(ctx, urlSignerKey, values.URLSigner)
+}
@@ -0,0 +1,195 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// errNoOverlap is returned by parseRange when no requested range overlaps the content
+var errNoOverlap = errors.New("invalid range: failed to overlap")
+
+// httpRange specifies the byte range to be sent to the client
+type httpRange struct {
+	start, length int64
+}
+
+func (r httpRange) contentRange(size int64) string {
+	return fmt.Sprintf("bytes %d-%d/%d", r.start, r.start+r.length-1, size)
+}
+
+// parseRange parses a Range header string as per RFC 9110
+// An empty header returns no ranges, meaning the full content should be sent
+func parseRange(header string, size int64) ([]httpRange, error) {
+	if header == "" {
+		return nil, nil
+	}
+	const prefix = "bytes="
+	if !strings.HasPrefix(header, prefix) {
+		return nil, errors.New("invalid range")
+	}
+
+	var ranges []httpRange
+	noOverlap := false
+	for _, spec := range strings.Split(header[len(prefix):], ",") {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+		startStr, endStr, ok := strings.Cut(spec, "-")
+		if !ok {
+			return nil, errors.New("invalid range")
+		}
+		startStr, endStr = strings.TrimSpace(startStr), strings.TrimSpace(endStr)
+
+		var r httpRange
+		if startStr == "" {
+			// Suffix range: the final N bytes
+			if endStr == "" || endStr[0] == '-' {
+				return nil, errors.New("invalid range")
+			}
+			n, err := strconv.ParseInt(endStr, 10, 64)
+			if err != nil {
+				return nil, errors.New("invalid range")
+			}
+			if n == 0 {
+				noOverlap = true
+				continue
+			}
+			if n > size {
+				n = size
+			}
+			r.start = size - n
+			r.length = size - r.start
+		} else {
+			start, err := strconv.ParseInt(startStr, 10, 64)
+			if err != nil || start < 0 {
+				return nil, errors.New("invalid range")
+			}
+			if start >= size {
+				noOverlap = true
+				continue
+			}
+			r.start = start
+			if endStr == "" {
+				r.length = size - start
+			} else {
+				end, err := strconv.ParseInt(endStr, 10, 64)
+				if err != nil || start > end {
+					return nil, errors.New("invalid range")
+				}
+				if end >= size {
+					end = size - 1
+				}
+				r.length = end - start + 1
+			}
+		}
+		ranges = append(ranges, r)
+	}
+
+	if noOverlap && len(ranges) == 0 {
+		return nil, errNoOverlap
+	}
+	return ranges, nil
+}
+
+// checkPreconditions evaluates conditional request headers as per RFC 9110 section 13.2.2
+// Returns 0 when the request should proceed, otherwise the status code to respond with
+func checkPreconditions(ctx Context, etag string, modTime time.Time) int {
+	if ifMatch := ctx.Header("If-Match"); ifMatch != "" {
+		if !etagListMatches(ifMatch, etag, false) {
+			return http.StatusPreconditionFailed
+		}
+	} else if ius := ctx.Header("If-Unmodified-Since"); ius != "" && !isZeroTime(modTime) {
+		if t, err := http.ParseTime(ius); err == nil && modTime.Truncate(time.Second).After(t) {
+			return http.StatusPreconditionFailed
+		}
+	}
+
+	method := ctx.Request().Method
+	safe := method == http.MethodGet || method == http.MethodHead
+	if ifNoneMatch := ctx.Header("If-None-Match"); ifNoneMatch != "" {
+		if etagListMatches(ifNoneMatch, etag, true) {
+			if safe {
+				return http.StatusNotModified
+			}
+			return http.StatusPreconditionFailed
+		}
+	} else if ims := ctx.Header("If-Modified-Since"); ims != "" && safe && !isZeroTime(modTime) {
+		if t, err := http.ParseTime(ims); err == nil && !modTime.Truncate(time.Second).After(t) {
+			return http.StatusNotModified
+		}
+	}
+	return 0
+}
+
+// ifRangeMatches reports whether a Range header should be honored given the If-Range header
+// If-Range requires a strong comparison for entity tags
+func ifRangeMatches(ifRange, etag string, modTime time.Time) bool {
+	if ifRange == "" {
+		return true
+	}
+	if strings.HasPrefix(ifRange, `"`) || strings.HasPrefix(ifRange, "W/") {
+		return !strings.HasPrefix(ifRange, "W/") && !strings.HasPrefix(etag, "W/") && ifRange == etag
+	}
+	if isZeroTime(modTime) {
+		return false
+	}
+	t, err := http.ParseTime(ifRange)
+	return err == nil && modTime.Truncate(time.Second).Equal(t)
+}
+
+// etagListMatches reports whether etag appears in a comma separated If-Match/If-None-Match list
+func etagListMatches(list, etag string, weak bool) bool {
+	if strings.TrimSpace(list) == "*" {
+		return true
+	}
+	for _, candidate := range strings.Split(list, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if weak {
+			if strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		} else if candidate == etag && !strings.HasPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// multipartRanges streams the requested ranges as a multipart/byteranges body
+// The returned reader closes file once the body has been fully written
+func multipartRanges(content io.ReadSeeker, file io.Closer, ranges []httpRange, contentType string, size int64) (io.ReadCloser, string) {
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+	go func() {
+		defer file.Close()
+		for _, ra := range ranges {
+			part, err := mw.CreatePart(textproto.MIMEHeader{
+				"Content-Range": {ra.contentRange(size)},
+				"Content-Type":  {contentType},
+			})
+			if err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+			if _, err := content.Seek(ra.start, io.SeekStart); err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+			if _, err := io.CopyN(part, content, ra.length); err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+		}
+		mw.Close()
+		pw.Close()
+	}()
+	return pr, mw.Boundary()
+}
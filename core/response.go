@@ -1,9 +1,11 @@
 package core
 
 import (
-	"encoding/json"
+	"bytes"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
 )
 
 // Cookie represents an HTTP cookie
@@ -102,15 +104,61 @@ func (r *Response) Send(ctx Context) error {
 		return err
 	}
 
-	// Otherwise, send as JSON
-	// Set content type if not already set
-	if _, exists := r.Headers["Content-Type"]; !exists {
-		ctx.SetHeader("Content-Type", "application/json")
+	// Readers are streamed as-is and closed afterwards if they implement io.Closer
+	if reader, ok := r.Body.(io.Reader); ok {
+		if closer, ok := reader.(io.Closer); ok {
+			defer closer.Close()
+		}
+		if _, exists := r.Headers["Content-Type"]; !exists {
+			ctx.SetHeader("Content-Type", "application/octet-stream")
+		}
+		ctx.Status(r.StatusCode)
+		_, err := io.Copy(ctx.Response(), reader)
+		return err
+	}
+
+	// Otherwise, encode with a codec
+	// A Content-Type set by the handler forces the format; without one it is negotiated from Accept
+	codecs := GetCodecs(ctx)
+	var codec Codec
+	if contentType, exists := r.Headers["Content-Type"]; exists {
+		codec = codecs.Lookup(contentType, r.Body)
+		if codec == nil {
+			codec = JSONCodec{Engine: GetJSONEngine(ctx)}
+		}
+	} else {
+		if vary, exists := r.Headers["Vary"]; exists {
+			ctx.SetHeader("Vary", vary+", Accept")
+		} else {
+			ctx.SetHeader("Vary", "Accept")
+		}
+		codec = codecs.Negotiate(ctx.Header("Accept"), r.Body)
+		if codec == nil && r.StatusCode >= 400 {
+			// An error is more useful in JSON than replaced by a 406
+			codec = JSONCodec{Engine: GetJSONEngine(ctx)}
+		}
+		if codec == nil {
+			return sendNotAcceptable(ctx, codecs)
+		}
+		ctx.SetHeader("Content-Type", codec.ContentType())
+	}
+
+	// Encode before writing the status so encoding errors can still become an error response
+	var buf bytes.Buffer
+	if err := codec.Encode(&buf, r.Body); err != nil {
+		return err
 	}
 	ctx.Status(r.StatusCode)
+	_, err := ctx.Response().Write(buf.Bytes())
+	return err
+}
 
-	encoder := json.NewEncoder(ctx.Response())
-	return encoder.Encode(r.Body)
+// sendNotAcceptable writes a 406 listing the media types the app can produce
+func sendNotAcceptable(ctx Context, codecs *CodecRegistry) error {
+	ctx.SetHeader("Content-Type", "text/plain")
+	ctx.Status(http.StatusNotAcceptable)
+	_, err := fmt.Fprintf(ctx.Response(), "Not Acceptable. Available: %s", strings.Join(codecs.ContentTypes(), ", "))
+	return err
 }
 
 // SendResponse is a helper to send a Response struct
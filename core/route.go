@@ -0,0 +1,215 @@
+package core
+
+import (
+	"reflect"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Route describes a registered route
+// Handle and the method helpers return it, so documentation can be chained onto a registration:
+//
+//	app.Get("/users/:id", getUser, useparams.Use[UserParams](paramsSchema)).
+//		WithSummary("Get a user").
+//		WithTags("users").
+//		WithResponse(200, "The user", UserResponse{})
+//
+// Hooks such as usejsonbody and useheaders record their schemas on the route they are applied to
+type Route struct {
+	Method      string
+	Path        string
+	Name        string
+	Summary     string
+	Description string
+	OperationID string
+	Tags        []string
+	Deprecated  bool
+
+	// Handler and Middlewares name the functions serving the route, e.g. "main.getUser" and "cors.New"
+	// Middlewares lists app-level middleware first, in the order they run
+	Handler     string
+	Middlewares []string
+
+	// Request parts recorded by the hooks, or by WithRequest
+	Params  *RouteSchema
+	Query   *RouteSchema
+	Headers *RouteSchema
+	Cookies *RouteSchema
+	Body    *RouteSchema
+
+	// Responses maps status codes to the documented responses
+	Responses map[int]*RouteResponse
+}
+
+// RouteSchema documents one part of a request
+// Schema is the zog schema validating it, if any, and Type the struct it is parsed into
+type RouteSchema struct {
+	Schema any
+	Type   reflect.Type
+}
+
+// NewRouteSchema documents a request part validated by schema and parsed into dest
+// dest is a value or a pointer of the parsed type
+func NewRouteSchema(schema any, dest any) *RouteSchema {
+	t := reflect.TypeOf(dest)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return &RouteSchema{Schema: schema, Type: t}
+}
+
+// RouteResponse documents a response of a route
+// Body is a zog schema, an openapi.Schema or a value of the Go type sent as the body; nil documents an empty response
+type RouteResponse struct {
+	Description string
+	ContentType string
+	Body        any
+}
+
+// NewRoute creates a route description for method and path
+func NewRoute(method, path string) *Route {
+	return &Route{
+		Method:    method,
+		Path:      path,
+		Responses: make(map[int]*RouteResponse),
+	}
+}
+
+// WithName names the route so App.URL and Context.URL can build its URL
+// Names should be unique; App.URL uses the first route registered with a name
+func (r *Route) WithName(name string) *Route {
+	r.Name = name
+	return r
+}
+
+// WithHandlerName sets the handler name shown in route listings
+// Use it for handlers built by wrappers such as Typed, which are otherwise listed as the wrapper
+func (r *Route) WithHandlerName(name string) *Route {
+	r.Handler = name
+	return r
+}
+
+// WithSummary sets a short summary of the route
+func (r *Route) WithSummary(summary string) *Route {
+	r.Summary = summary
+	return r
+}
+
+// WithDescription sets a longer description of the route
+func (r *Route) WithDescription(description string) *Route {
+	r.Description = description
+	return r
+}
+
+// WithOperationID sets the route's unique operation id
+func (r *Route) WithOperationID(id string) *Route {
+	r.OperationID = id
+	return r
+}
+
+// WithTags adds tags used to group the route
+func (r *Route) WithTags(tags ...string) *Route {
+	r.Tags = append(r.Tags, tags...)
+	return r
+}
+
+// WithDeprecated marks the route as deprecated
+func (r *Route) WithDeprecated() *Route {
+	r.Deprecated = true
+	return r
+}
+
+// WithResponse documents a JSON response for status
+// body is a zog schema or a value of the Go type sent as the body, e.g. UserResponse{}
+func (r *Route) WithResponse(status int, description string, body any) *Route {
+	return r.WithResponseType(status, description, "application/json", body)
+}
+
+// WithResponseType documents a response for status with the given content type
+func (r *Route) WithResponseType(status int, description, contentType string, body any) *Route {
+	r.Responses[status] = &RouteResponse{
+		Description: description,
+		ContentType: contentType,
+		Body:        body,
+	}
+	return r
+}
+
+// WithRequest documents the request from a struct bound by Typed
+// Fields tagged `param`, `query` and `header` become parameters, and the field tagged `body`
+// or, without one, the whole struct becomes the request body
+func (r *Route) WithRequest(req any) *Route {
+	t := reflect.TypeOf(req)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return r
+	}
+	r.Params = &RouteSchema{Type: t}
+	r.Query = &RouteSchema{Type: t}
+	r.Headers = &RouteSchema{Type: t}
+	if index := findBodyField(t); index != nil {
+		r.Body = &RouteSchema{Type: t.FieldByIndex(index).Type}
+	} else if r.Method != "GET" && r.Method != "HEAD" && r.Method != "DELETE" {
+		r.Body = &RouteSchema{Type: t}
+	}
+	return r
+}
+
+var (
+	// describeMu serializes route registration so middleware can find the route being built
+	describeMu sync.Mutex
+	// describing is the route being registered; it is atomic so DescribeRoute can read it without the lock
+	describing atomic.Pointer[Route]
+)
+
+// ApplyRoute applies middlewares to a handler like Apply while registering route
+// Middleware can describe the route with DescribeRoute while they are applied
+func ApplyRoute(route *Route, handler Handler, middlewares ...Middleware) Handler {
+	describeMu.Lock()
+	defer describeMu.Unlock()
+
+	route.Handler = funcName(handler)
+	route.Middlewares = make([]string, len(middlewares))
+	for i, middleware := range middlewares {
+		route.Middlewares[i] = funcName(middleware)
+	}
+
+	describing.Store(route)
+	defer describing.Store(nil)
+	return Compose(middlewares...)(handler)
+}
+
+// DescribeRoute lets a middleware document the route it is applied to
+// Call it when the middleware wraps the next handler; outside ApplyRoute it does nothing
+func DescribeRoute(describe func(route *Route)) {
+	if route := describing.Load(); route != nil {
+		describe(route)
+	}
+}
+
+// closureSuffix matches the suffixes Go adds to the names of closures and method values
+var closureSuffix = regexp.MustCompile(`(\.func\d+|\.\d+|-fm)+$`)
+
+// funcName returns the name of fn without its package path
+// Closures are named after the function creating them, so the middleware returned by cors.New is "cors.New"
+func funcName(fn any) string {
+	v := reflect.ValueOf(fn)
+	if v.Kind() != reflect.Func || v.IsNil() {
+		return ""
+	}
+	f := runtime.FuncForPC(v.Pointer())
+	if f == nil {
+		return ""
+	}
+	name := closureSuffix.ReplaceAllString(f.Name(), "")
+	// Type parameters are printed as "[...]", so the last slash ends the package path
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
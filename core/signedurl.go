@@ -0,0 +1,168 @@
+package core
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Query parameters a signed URL carries
+const (
+	SignatureParam = "signature"
+	ExpiresParam   = "expires"
+	// BindParam lists the query parameters covered by the signature
+	BindParam = "bind"
+	// BindIPParam marks a signature bound to the client IP
+	BindIPParam = "bind_ip"
+)
+
+var (
+	// ErrInvalidSignature is returned for URLs that are unsigned, tampered with or signed with an unknown key
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrSignatureExpired is returned for correctly signed URLs past their expiry
+	ErrSignatureExpired = errors.New("signature expired")
+)
+
+// URLSigner signs URLs with HMAC-SHA256 so they can be handed out as temporary links
+// The first key signs; every key verifies, so keys can be rotated by adding the new key first
+// and removing the old one once the URLs it signed have expired
+type URLSigner struct {
+	keys [][]byte
+}
+
+// NewURLSigner creates a signer from secret keys, newest first
+// Keys should be at least 32 random bytes
+func NewURLSigner(keys ...[]byte) *URLSigner {
+	return &URLSigner{keys: keys}
+}
+
+// SignOptions selects what a signature covers besides the path and expiry
+type SignOptions struct {
+	// Query lists the query parameters the signature covers; nil covers every parameter in the URL
+	// Parameters left out can be changed or added without invalidating the signature
+	Query []string
+	// ClientIP binds the signature to a client address, e.g. ClientIP(ctx.Request())
+	ClientIP string
+}
+
+// Sign returns rawURL signed to expire after ttl
+// rawURL is a path with an optional query, such as one built by App.URL
+func (s *URLSigner) Sign(rawURL string, ttl time.Duration, options *SignOptions) (string, error) {
+	if len(s.keys) == 0 {
+		return "", errors.New("url signer has no keys")
+	}
+	if ttl <= 0 {
+		return "", fmt.Errorf("invalid ttl %s", ttl)
+	}
+	if options == nil {
+		options = &SignOptions{}
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	query := u.Query()
+	for _, param := range []string{SignatureParam, ExpiresParam, BindParam, BindIPParam} {
+		query.Del(param)
+	}
+	bound := options.Query
+	if bound == nil {
+		for name := range query {
+			bound = append(bound, name)
+		}
+		sort.Strings(bound)
+	}
+	if len(bound) > 0 {
+		query.Set(BindParam, strings.Join(bound, ","))
+	}
+	if options.ClientIP != "" {
+		query.Set(BindIPParam, "1")
+	}
+	query.Set(ExpiresParam, strconv.FormatInt(time.Now().Add(ttl).Unix(), 10))
+	query.Set(SignatureParam, s.mac(s.keys[0], u.EscapedPath(), query, options.ClientIP))
+
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// Verify checks the signature and expiry of a request's URL
+// clientIP is compared when the URL was signed for a client address
+func (s *URLSigner) Verify(req *http.Request, clientIP string) error {
+	query := req.URL.Query()
+	signature := query.Get(SignatureParam)
+	expires, err := strconv.ParseInt(query.Get(ExpiresParam), 10, 64)
+	if signature == "" || err != nil {
+		return ErrInvalidSignature
+	}
+	if query.Get(BindIPParam) == "" {
+		clientIP = ""
+	}
+
+	valid := false
+	for _, key := range s.keys {
+		expected := s.mac(key, req.URL.EscapedPath(), query, clientIP)
+		if hmac.Equal([]byte(signature), []byte(expected)) {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return ErrInvalidSignature
+	}
+	if time.Now().Unix() > expires {
+		return ErrSignatureExpired
+	}
+	return nil
+}
+
+// mac signs the path, the signing parameters, the bound query parameters and the client IP
+func (s *URLSigner) mac(key []byte, path string, query url.Values, clientIP string) string {
+	bound := url.Values{}
+	if names := query.Get(BindParam); names != "" {
+		for _, name := range strings.Split(names, ",") {
+			if values, ok := query[name]; ok {
+				bound[name] = values
+			}
+		}
+	}
+
+	h := hmac.New(sha256.New, key)
+	for _, part := range []string{
+		path,
+		query.Get(ExpiresParam),
+		query.Get(BindParam),
+		bound.Encode(),
+		query.Get(BindIPParam),
+		clientIP,
+	} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil))
+}
+
+// ClientIP returns the address the request came from, without the port
+// Behind a proxy this is the proxy's address; read the forwarded address the proxy sets instead
+func ClientIP(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return host
+}
+
+// GetURLSigner returns the signer of the app serving ctx, or nil if it has none
+func GetURLSigner(ctx Context) *URLSigner {
+	signer, _ := GetValue(ctx, urlSignerKey)
+	return signer
+}
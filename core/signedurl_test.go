@@ -0,0 +1,109 @@
+package core
+
+import (
+	"errors"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestURLSigner(t *testing.T) {
+	oldKey := []byte("old-key-0123456789abcdef0123456789")
+	newKey := []byte("new-key-0123456789abcdef0123456789")
+
+	// setQuery returns a tamper func setting a query parameter of the signed URL
+	setQuery := func(name, value string) func(u *url.URL) {
+		return func(u *url.URL) {
+			query := u.Query()
+			query.Set(name, value)
+			u.RawQuery = query.Encode()
+		}
+	}
+	delQuery := func(name string) func(u *url.URL) {
+		return func(u *url.URL) {
+			query := u.Query()
+			query.Del(name)
+			u.RawQuery = query.Encode()
+		}
+	}
+
+	tests := []struct {
+		name     string
+		signer   *URLSigner
+		verifier *URLSigner
+		url      string
+		options  *SignOptions
+		tamper   func(u *url.URL)
+		clientIP string
+		want     error
+	}{
+		{name: "valid", url: "/files/report.pdf?user=1"},
+		{name: "tampered path", url: "/files/report.pdf", tamper: func(u *url.URL) { u.Path = "/files/other.pdf" }, want: ErrInvalidSignature},
+		{name: "tampered query value", url: "/files/report.pdf?user=1", tamper: setQuery("user", "2"), want: ErrInvalidSignature},
+		{name: "removed query value", url: "/files/report.pdf?user=1", tamper: delQuery("user"), want: ErrInvalidSignature},
+		{name: "unbound query value", url: "/files/report.pdf?user=1", options: &SignOptions{Query: []string{"user"}}, tamper: setQuery("page", "2")},
+		{name: "tampered bind list", url: "/files/report.pdf?user=1&page=1", tamper: setQuery(BindParam, "page"), want: ErrInvalidSignature},
+		{name: "stripped bind list", url: "/files/report.pdf?user=1", tamper: delQuery(BindParam), want: ErrInvalidSignature},
+		{name: "tampered expiry", url: "/files/report.pdf", tamper: setQuery(ExpiresParam, "99999999999"), want: ErrInvalidSignature},
+		{name: "missing signature", url: "/files/report.pdf", tamper: delQuery(SignatureParam), want: ErrInvalidSignature},
+		{name: "bound client IP", url: "/files/report.pdf", options: &SignOptions{ClientIP: "10.0.0.1"}, clientIP: "10.0.0.1"},
+		{name: "other client IP", url: "/files/report.pdf", options: &SignOptions{ClientIP: "10.0.0.1"}, clientIP: "10.0.0.2", want: ErrInvalidSignature},
+		{name: "stripped bind_ip", url: "/files/report.pdf", options: &SignOptions{ClientIP: "10.0.0.1"}, tamper: delQuery(BindIPParam), clientIP: "10.0.0.2", want: ErrInvalidSignature},
+		{name: "new key first", signer: NewURLSigner(oldKey), verifier: NewURLSigner(newKey, oldKey), url: "/files/report.pdf"},
+		{name: "signed with the new key", signer: NewURLSigner(newKey, oldKey), verifier: NewURLSigner(newKey), url: "/files/report.pdf"},
+		{name: "rotated-out key", signer: NewURLSigner(oldKey), verifier: NewURLSigner(newKey), url: "/files/report.pdf", want: ErrInvalidSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer, verifier := tt.signer, tt.verifier
+			if signer == nil {
+				signer = NewURLSigner(newKey)
+			}
+			if verifier == nil {
+				verifier = signer
+			}
+
+			signed, err := signer.Sign(tt.url, time.Hour, tt.options)
+			if err != nil {
+				t.Fatalf("Sign(%q): %v", tt.url, err)
+			}
+			u, err := url.Parse(signed)
+			if err != nil {
+				t.Fatalf("Sign(%q) = %q: %v", tt.url, signed, err)
+			}
+			if tt.tamper != nil {
+				tt.tamper(u)
+			}
+
+			err = verifier.Verify(httptest.NewRequest("GET", u.String(), nil), tt.clientIP)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Verify(%q) = %v, want %v", u, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestURLSignerExpired(t *testing.T) {
+	signer := NewURLSigner([]byte("key-0123456789abcdef0123456789abcd"))
+
+	// Sign cannot produce a URL in the past, so sign one by hand like Sign does
+	query := url.Values{}
+	query.Set(ExpiresParam, strconv.FormatInt(time.Now().Add(-time.Minute).Unix(), 10))
+	query.Set(SignatureParam, signer.mac(signer.keys[0], "/files/report.pdf", query, ""))
+	req := httptest.NewRequest("GET", "/files/report.pdf?"+query.Encode(), nil)
+
+	if err := signer.Verify(req, ""); !errors.Is(err, ErrSignatureExpired) {
+		t.Errorf("Verify = %v, want %v", err, ErrSignatureExpired)
+	}
+}
+
+func TestURLSignerSignErrors(t *testing.T) {
+	if _, err := NewURLSigner().Sign("/files", time.Hour, nil); err == nil {
+		t.Error("Sign without keys succeeded, want an error")
+	}
+	if _, err := NewURLSigner([]byte("key")).Sign("/files", 0, nil); err == nil {
+		t.Error("Sign with a zero ttl succeeded, want an error")
+	}
+}
@@ -0,0 +1,225 @@
+package core
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SPAOptions represents single-page-app hosting configuration options
+type SPAOptions struct {
+	// Index is the HTML entry point served for unknown paths
+	Index string
+	// APIPrefixes lists path prefixes that never fall back to the index (e.g. "/api")
+	// Unknown paths under these prefixes get the regular JSON 404; use an empty slice for none
+	APIPrefixes []string
+	// HashedAssets matches file names that contain a content hash and can be cached forever
+	HashedAssets *regexp.Regexp
+	// AssetMaxAge is the Cache-Control max-age for hashed assets
+	AssetMaxAge time.Duration
+	// RuntimeConfig is marshaled to JSON and injected into the index as window[ConfigVariable]
+	RuntimeConfig any
+	// ConfigVariable is the global variable name that holds RuntimeConfig in the browser
+	ConfigVariable string
+	// Static configures how non-index files are served
+	Static *StaticOptions
+}
+
+// DefaultSPAOptions returns default single-page-app options
+func DefaultSPAOptions() *SPAOptions {
+	return &SPAOptions{
+		Index:          "index.html",
+		APIPrefixes:    []string{"/api"},
+		HashedAssets:   regexp.MustCompile(`[.-][0-9A-Za-z_-]{8,}\.[0-9A-Za-z]+$`),
+		AssetMaxAge:    365 * 24 * time.Hour,
+		ConfigVariable: "__APP_CONFIG__",
+		Static:         DefaultStaticOptions(),
+	}
+}
+
+// withSPADefaults returns a copy of options with zero-valued fields set from DefaultSPAOptions
+func withSPADefaults(options *SPAOptions) *SPAOptions {
+	defaults := DefaultSPAOptions()
+	if options == nil {
+		return defaults
+	}
+	copied := *options
+	if copied.Index == "" {
+		copied.Index = defaults.Index
+	}
+	if copied.APIPrefixes == nil {
+		copied.APIPrefixes = defaults.APIPrefixes
+	}
+	if copied.HashedAssets == nil {
+		copied.HashedAssets = defaults.HashedAssets
+	}
+	if copied.AssetMaxAge == 0 {
+		copied.AssetMaxAge = defaults.AssetMaxAge
+	}
+	if copied.ConfigVariable == "" {
+		copied.ConfigVariable = defaults.ConfigVariable
+	}
+	if copied.Static == nil {
+		copied.Static = defaults.Static
+	}
+	return &copied
+}
+
+// spaServer serves a single-page-app build directory with history-API fallback
+type spaServer struct {
+	static  *staticServer
+	options *SPAOptions
+	index   []byte
+	etag    string
+}
+
+// SPAHandler returns a handler that serves a single-page-app from fsys
+// Existing files are served as static files; any other GET or HEAD request outside
+// the API prefixes receives the index, with RuntimeConfig injected once at startup
+// It is meant to be installed as the app's NotFound handler
+// Fields left at their zero value take the value from DefaultSPAOptions
+func SPAHandler(fsys fs.FS, options *SPAOptions) (Handler, error) {
+	options = withSPADefaults(options)
+
+	index, err := fs.ReadFile(fsys, options.Index)
+	if err != nil {
+		return nil, fmt.Errorf("spa: failed to read index %q: %w", options.Index, err)
+	}
+	if options.RuntimeConfig != nil {
+		index, err = injectRuntimeConfig(index, options.ConfigVariable, options.RuntimeConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+	sum := sha256.Sum256(index)
+
+	// Serve assets with the configured static options, overriding the cache policy
+	copiedStatic := *options.Static
+	staticOptions := &copiedStatic
+	staticOptions.Index = nil
+	staticOptions.Browse = false
+	assetCacheControl := fmt.Sprintf("public, max-age=%d, immutable", int64(options.AssetMaxAge.Seconds()))
+	userCacheControl := staticOptions.CacheControl
+	staticOptions.CacheControl = func(name string) string {
+		if options.HashedAssets.MatchString(path.Base(name)) {
+			return assetCacheControl
+		}
+		if userCacheControl != nil {
+			if cacheControl := userCacheControl(name); cacheControl != "" {
+				return cacheControl
+			}
+		}
+		if staticOptions.MaxAge > 0 {
+			return ""
+		}
+		return "no-cache"
+	}
+
+	s := &spaServer{
+		static:  newStaticServer(fsys, staticOptions),
+		options: options,
+		index:   index,
+		etag:    `"` + hex.EncodeToString(sum[:16]) + `"`,
+	}
+	return s.serve, nil
+}
+
+func (s *spaServer) serve(ctx Context) (*Response, error) {
+	req := ctx.Request()
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return NotFoundHandler(ctx)
+	}
+	for _, prefix := range s.options.APIPrefixes {
+		if hasPathPrefix(req.URL.Path, prefix) {
+			return NotFoundHandler(ctx)
+		}
+	}
+
+	// The index is always served from memory so it carries the runtime config
+	name, ok := cleanStaticPath(req.URL.Path)
+	if !ok || name == "." || name == s.options.Index {
+		return s.serveIndex(ctx)
+	}
+
+	if info, err := fs.Stat(s.static.fsys, name); err == nil && !info.IsDir() {
+		resp, err := s.static.serve(ctx, name)
+		if err == nil || !isNotFound(err) {
+			return resp, err
+		}
+	}
+	return s.serveIndex(ctx)
+}
+
+// serveIndex writes the prepared index with no-cache so new deployments are picked up immediately
+func (s *spaServer) serveIndex(ctx Context) (*Response, error) {
+	resp := NewResponse().
+		WithHeader("Cache-Control", "no-cache").
+		WithHeader("ETag", s.etag)
+	if etagListMatches(ctx.Header("If-None-Match"), s.etag, true) {
+		return resp.WithStatus(http.StatusNotModified), nil
+	}
+
+	resp.WithHeader("Content-Type", "text/html; charset=utf-8").
+		WithHeader("Content-Length", strconv.Itoa(len(s.index)))
+	if ctx.Request().Method == http.MethodHead {
+		return resp, nil
+	}
+	return resp.WithBody(bytes.NewReader(s.index)), nil
+}
+
+// injectRuntimeConfig adds a script defining window[variable] before </head>
+// Falls back to the start of <body>, or the start of the document
+func injectRuntimeConfig(index []byte, variable string, config any) ([]byte, error) {
+	// json.Marshal escapes <, > and & so the payload cannot terminate the script element
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("spa: failed to marshal runtime config: %w", err)
+	}
+	variableName, err := json.Marshal(variable)
+	if err != nil {
+		return nil, err
+	}
+	script := fmt.Sprintf("<script>window[%s]=%s;</script>", variableName, data)
+
+	lower := bytes.ToLower(index)
+	at := bytes.Index(lower, []byte("</head>"))
+	if at < 0 {
+		if body := bytes.Index(lower, []byte("<body")); body >= 0 {
+			if end := bytes.IndexByte(lower[body:], '>'); end >= 0 {
+				at = body + end + 1
+			}
+		}
+	}
+	if at < 0 {
+		at = 0
+	}
+
+	result := make([]byte, 0, len(index)+len(script))
+	result = append(result, index[:at]...)
+	result = append(result, script...)
+	result = append(result, index[at:]...)
+	return result, nil
+}
+
+// hasPathPrefix reports whether urlPath is prefix or lies beneath it
+func hasPathPrefix(urlPath, prefix string) bool {
+	prefix = strings.TrimRight(prefix, "/")
+	if prefix == "" {
+		return true
+	}
+	return urlPath == prefix || strings.HasPrefix(urlPath, prefix+"/")
+}
+
+func isNotFound(err error) bool {
+	httpErr := GetHTTPError(err)
+	return httpErr != nil && httpErr.Code == http.StatusNotFound
+}
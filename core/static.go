@@ -0,0 +1,418 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StaticParam is the name of the catch-all path parameter used by static routes
+const StaticParam = "filepath"
+
+// StaticOptions represents static file serving configuration options
+type StaticOptions struct {
+	// Index lists the file names tried, in order, when a directory is requested
+	Index []string
+	// Browse enables HTML directory listings when no index file exists
+	Browse bool
+	// AllowDotfiles allows serving files and directories whose name starts with "."
+	AllowDotfiles bool
+	// Precompressed serves "<name>.br" or "<name>.gz" siblings when the client accepts them
+	Precompressed bool
+	// MaxAge sets the Cache-Control max-age; zero omits the header
+	MaxAge time.Duration
+	// CacheControl returns the Cache-Control value for a file, overriding MaxAge when non-empty
+	CacheControl func(name string) string
+}
+
+// DefaultStaticOptions returns default static file serving options
+func DefaultStaticOptions() *StaticOptions {
+	return &StaticOptions{
+		Index: []string{"index.html"},
+	}
+}
+
+// StaticPattern returns the catch-all route pattern used to mount static files under prefix
+// Example: /assets -> /assets/*filepath
+func StaticPattern(prefix string) string {
+	return strings.TrimRight(prefix, "/") + "/*" + StaticParam
+}
+
+// precompressedEncodings lists the sibling encodings tried when Precompressed is enabled, in preference order
+var precompressedEncodings = []struct {
+	encoding  string
+	extension string
+}{
+	{"br", ".br"},
+	{"gzip", ".gz"},
+}
+
+// staticServer serves files from an fs.FS
+type staticServer struct {
+	fsys    fs.FS
+	options *StaticOptions
+	// etags caches content hashes for files without a modification time (e.g. embed.FS)
+	etags sync.Map
+}
+
+// StaticHandler returns a handler that serves files from fsys
+// The file path is taken from the StaticParam path parameter, so the handler
+// must be registered on a pattern built with StaticPattern
+func StaticHandler(fsys fs.FS, options *StaticOptions) Handler {
+	s := newStaticServer(fsys, options)
+	return func(ctx Context) (*Response, error) {
+		return s.serve(ctx, ctx.Param(StaticParam))
+	}
+}
+
+func newStaticServer(fsys fs.FS, options *StaticOptions) *staticServer {
+	if options == nil {
+		options = DefaultStaticOptions()
+	}
+	return &staticServer{fsys: fsys, options: options}
+}
+
+// serve serves the file or directory at filePath, relative to the root of the file system
+func (s *staticServer) serve(ctx Context, filePath string) (*Response, error) {
+	name, ok := cleanStaticPath(filePath)
+	if !ok {
+		return nil, NewError(400, "Invalid path")
+	}
+	if !s.options.AllowDotfiles && hasDotSegment(name) {
+		return nil, ErrNotFound
+	}
+
+	info, err := fs.Stat(s.fsys, name)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	urlPath := ctx.Request().URL.Path
+	if info.IsDir() {
+		// Relative links inside index files and listings only resolve with a trailing slash
+		if !strings.HasSuffix(urlPath, "/") {
+			return redirectResponse(ctx, urlPath+"/"), nil
+		}
+		for _, index := range s.options.Index {
+			indexName := path.Join(name, index)
+			if indexInfo, err := fs.Stat(s.fsys, indexName); err == nil && !indexInfo.IsDir() {
+				return s.serveFile(ctx, indexName, indexInfo)
+			}
+		}
+		if s.options.Browse {
+			return s.serveListing(ctx, name)
+		}
+		return nil, ErrNotFound
+	}
+
+	if strings.HasSuffix(urlPath, "/") && name != "." {
+		return redirectResponse(ctx, strings.TrimRight(urlPath, "/")), nil
+	}
+	return s.serveFile(ctx, name, info)
+}
+
+// serveFile writes a single file, honoring conditional and range requests
+func (s *staticServer) serveFile(ctx Context, name string, info fs.FileInfo) (*Response, error) {
+	contentType := mime.TypeByExtension(path.Ext(name))
+
+	// Pick a precompressed sibling if the client accepts it
+	servedName, servedInfo, encoding := name, info, ""
+	if s.options.Precompressed {
+		ctx.SetHeader("Vary", "Accept-Encoding")
+		acceptEncoding := ctx.Header("Accept-Encoding")
+		for _, candidate := range precompressedEncodings {
+			if !acceptsEncoding(acceptEncoding, candidate.encoding) {
+				continue
+			}
+			siblingInfo, err := fs.Stat(s.fsys, name+candidate.extension)
+			if err == nil && !siblingInfo.IsDir() {
+				servedName, servedInfo, encoding = name+candidate.extension, siblingInfo, candidate.encoding
+				break
+			}
+		}
+	}
+
+	file, err := s.fsys.Open(servedName)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	content, size, err := seekableContent(file, servedInfo)
+	if err != nil {
+		file.Close()
+		return nil, WrapError(500, "Failed to read file", err)
+	}
+
+	// Sniff the content type of the original (uncompressed) file when the extension is unknown
+	if contentType == "" {
+		contentType, err = s.sniffContentType(name, content, encoding)
+		if err != nil {
+			file.Close()
+			return nil, WrapError(500, "Failed to read file", err)
+		}
+	}
+
+	etag, err := s.etag(servedName, servedInfo, content)
+	if err != nil {
+		file.Close()
+		return nil, WrapError(500, "Failed to read file", err)
+	}
+	if encoding != "" {
+		etag = strings.TrimSuffix(etag, `"`) + "-" + encoding + `"`
+	}
+	modTime := servedInfo.ModTime()
+
+	validators := map[string]string{"ETag": etag}
+	if !isZeroTime(modTime) {
+		validators["Last-Modified"] = modTime.UTC().Format(http.TimeFormat)
+	}
+	if s.options.CacheControl != nil {
+		if cacheControl := s.options.CacheControl(name); cacheControl != "" {
+			validators["Cache-Control"] = cacheControl
+		}
+	}
+	if _, exists := validators["Cache-Control"]; !exists && s.options.MaxAge > 0 {
+		validators["Cache-Control"] = fmt.Sprintf("public, max-age=%d", int64(s.options.MaxAge.Seconds()))
+	}
+
+	if status := checkPreconditions(ctx, etag, modTime); status != 0 {
+		file.Close()
+		resp := NewResponse().WithStatus(status)
+		if status == http.StatusNotModified {
+			for k, v := range validators {
+				resp.WithHeader(k, v)
+			}
+		}
+		return resp, nil
+	}
+
+	resp := NewResponse().
+		WithHeader("Accept-Ranges", "bytes").
+		WithHeader("Content-Type", contentType)
+	for k, v := range validators {
+		resp.WithHeader(k, v)
+	}
+	if encoding != "" {
+		resp.WithHeader("Content-Encoding", encoding)
+	}
+
+	rangeHeader := ctx.Header("Range")
+	if rangeHeader != "" && !ifRangeMatches(ctx.Header("If-Range"), etag, modTime) {
+		rangeHeader = ""
+	}
+	ranges, err := parseRange(rangeHeader, size)
+	if err != nil {
+		file.Close()
+		return resp.
+			WithStatus(http.StatusRequestedRangeNotSatisfiable).
+			WithHeader("Content-Range", fmt.Sprintf("bytes */%d", size)).
+			WithHeader("Content-Type", "text/plain").
+			WithBody(err.Error()), nil
+	}
+
+	isHead := ctx.Request().Method == http.MethodHead
+	switch {
+	case len(ranges) == 1:
+		ra := ranges[0]
+		if _, err := content.Seek(ra.start, io.SeekStart); err != nil {
+			file.Close()
+			return nil, WrapError(500, "Failed to read file", err)
+		}
+		resp.WithStatus(http.StatusPartialContent).
+			WithHeader("Content-Range", ra.contentRange(size)).
+			WithHeader("Content-Length", strconv.FormatInt(ra.length, 10))
+		if isHead {
+			file.Close()
+			return resp, nil
+		}
+		return resp.WithBody(readCloser{io.LimitReader(content, ra.length), file}), nil
+
+	case len(ranges) > 1:
+		body, boundary := multipartRanges(content, file, ranges, contentType, size)
+		resp.WithStatus(http.StatusPartialContent).
+			WithHeader("Content-Type", "multipart/byteranges; boundary="+boundary)
+		if isHead {
+			body.Close()
+			return resp, nil
+		}
+		return resp.WithBody(body), nil
+	}
+
+	resp.WithHeader("Content-Length", strconv.FormatInt(size, 10))
+	if isHead {
+		file.Close()
+		return resp, nil
+	}
+	return resp.WithBody(readCloser{content, file}), nil
+}
+
+// serveListing writes an HTML listing of a directory
+func (s *staticServer) serveListing(ctx Context, name string) (*Response, error) {
+	entries, err := fs.ReadDir(s.fsys, name)
+	if err != nil {
+		return nil, WrapError(500, "Failed to read directory", err)
+	}
+
+	var b strings.Builder
+	title := html.EscapeString(ctx.Request().URL.Path)
+	fmt.Fprintf(&b, "<!doctype html>\n<meta name=\"viewport\" content=\"width=device-width\">\n<title>%s</title>\n<h1>%s</h1>\n<pre>\n", title, title)
+	for _, entry := range entries {
+		entryName := entry.Name()
+		if !s.options.AllowDotfiles && strings.HasPrefix(entryName, ".") {
+			continue
+		}
+		if entry.IsDir() {
+			entryName += "/"
+		}
+		href := (&url.URL{Path: entryName}).String()
+		fmt.Fprintf(&b, "<a href=\"%s\">%s</a>\n", html.EscapeString(href), html.EscapeString(entryName))
+	}
+	b.WriteString("</pre>\n")
+
+	resp := NewResponse().
+		WithHeader("Content-Type", "text/html; charset=utf-8")
+	if ctx.Request().Method == http.MethodHead {
+		return resp, nil
+	}
+	return resp.WithBody(b.String()), nil
+}
+
+// sniffContentType detects the content type from the first 512 bytes of the uncompressed file
+func (s *staticServer) sniffContentType(name string, content io.ReadSeeker, encoding string) (string, error) {
+	reader := io.Reader(content)
+	if encoding != "" {
+		// The served content is compressed, sniff the original file instead
+		original, err := s.fsys.Open(name)
+		if err != nil {
+			return "", err
+		}
+		defer original.Close()
+		reader = original
+	}
+
+	var buf [512]byte
+	n, err := io.ReadFull(reader, buf[:])
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+	if encoding == "" {
+		if _, err := content.Seek(0, io.SeekStart); err != nil {
+			return "", err
+		}
+	}
+	return http.DetectContentType(buf[:n]), nil
+}
+
+// etag returns the entity tag for a file
+// Files with a modification time use size and mtime; others (e.g. embed.FS) use a content hash
+func (s *staticServer) etag(name string, info fs.FileInfo, content io.ReadSeeker) (string, error) {
+	if !isZeroTime(info.ModTime()) {
+		return fmt.Sprintf(`"%x-%x"`, info.ModTime().UnixNano(), info.Size()), nil
+	}
+	if cached, ok := s.etags.Load(name); ok {
+		return cached.(string), nil
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, content); err != nil {
+		return "", err
+	}
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	etag := `"` + hex.EncodeToString(hash.Sum(nil)[:16]) + `"`
+	s.etags.Store(name, etag)
+	return etag, nil
+}
+
+// cleanStaticPath converts a catch-all parameter into an fs.FS path
+// Returns false for paths that try to escape the root
+func cleanStaticPath(param string) (string, bool) {
+	if strings.ContainsAny(param, "\\\x00") {
+		return "", false
+	}
+	for _, segment := range strings.Split(param, "/") {
+		if segment == ".." {
+			return "", false
+		}
+	}
+	name := strings.TrimPrefix(path.Clean("/"+param), "/")
+	if name == "" {
+		name = "."
+	}
+	return name, fs.ValidPath(name)
+}
+
+// hasDotSegment reports whether any element of name starts with "."
+func hasDotSegment(name string) bool {
+	if name == "." {
+		return false
+	}
+	for _, segment := range strings.Split(name, "/") {
+		if strings.HasPrefix(segment, ".") {
+			return true
+		}
+	}
+	return false
+}
+
+// seekableContent returns a seekable view of file and its size
+// Files that cannot seek are buffered in memory
+func seekableContent(file fs.File, info fs.FileInfo) (io.ReadSeeker, int64, error) {
+	if seeker, ok := file.(io.ReadSeeker); ok {
+		return seeker, info.Size(), nil
+	}
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, 0, err
+	}
+	return strings.NewReader(string(data)), int64(len(data)), nil
+}
+
+// acceptsEncoding reports whether an Accept-Encoding header allows encoding
+func acceptsEncoding(header, encoding string) bool {
+	for _, part := range strings.Split(header, ",") {
+		token, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if !strings.EqualFold(strings.TrimSpace(token), encoding) {
+			continue
+		}
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if value, err := strconv.ParseFloat(q, 64); err == nil && value == 0 {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// redirectResponse builds a 301 redirect to target, preserving the query string
+func redirectResponse(ctx Context, target string) *Response {
+	location := (&url.URL{Path: target}).EscapedPath()
+	if rawQuery := ctx.Request().URL.RawQuery; rawQuery != "" {
+		location += "?" + rawQuery
+	}
+	return NewResponse().
+		WithStatus(http.StatusMovedPermanently).
+		WithHeader("Location", location)
+}
+
+func isZeroTime(t time.Time) bool {
+	return t.IsZero() || t.Equal(time.Unix(0, 0))
+}
+
+// readCloser pairs a reader with the file that must be closed once it has been sent
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,231 @@
+package core
+
+import (
+	"fmt"
+	"net/http"
+	"net/textproto"
+	"reflect"
+	"strings"
+
+	z "github.com/Oudwins/zog"
+)
+
+// StatusCoder lets a typed handler's response choose its status code
+type StatusCoder interface {
+	StatusCode() int
+}
+
+// HeaderSetter lets a typed handler's response add response headers
+type HeaderSetter interface {
+	ResponseHeaders() map[string]string
+}
+
+// Validator validates a bound request; zog struct schemas implement it
+type Validator interface {
+	Validate(dataPtr any, options ...z.ExecOption) z.ZogIssueList
+}
+
+// TypedOption configures a typed handler
+type TypedOption func(*typedConfig)
+
+type typedConfig struct {
+	validator Validator
+}
+
+// WithValidator validates every bound request with a zog schema before the handler runs
+func WithValidator(validator Validator) TypedOption {
+	return func(c *typedConfig) {
+		c.validator = validator
+	}
+}
+
+// Typed turns a function taking a typed request and returning a typed response into a Handler
+// Req is a struct (or pointer to one) bound from the request:
+//   - fields tagged `param:"id"`, `query:"page"` or `header:"X-Token"` come from the path parameters,
+//     query string or headers, using the same tags as the useparams, usequery and useheaders hooks
+//   - the body is decoded with Bind into the field tagged `body:""` if there is one, else into the whole struct;
+//     fields tagged `param`, `query` or `header` are never set from the body
+//   - a tag may list alternative names, e.g. `header:"X-Api-Key,Authorization"`; the first one present wins
+//
+// The body is only read when the request has a Content-Type. Conversion failures are reported
+// together as a ValidationError, as are issues found by WithValidator
+// The response becomes the body of a 200 response; implement StatusCoder and HeaderSetter on Resp
+// to change the status and add headers. A nil pointer response sends 204, and a *Response is sent as is
+// Route listings show the handler as core.Typed[...] unless the route is named with Route.WithHandlerName
+func Typed[Req, Resp any](fn func(ctx Context, req Req) (Resp, error), options ...TypedOption) Handler {
+	config := &typedConfig{}
+	for _, option := range options {
+		option(config)
+	}
+
+	reqType := reflect.TypeOf((*Req)(nil)).Elem()
+	structType := reqType
+	if structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("core.Typed: request type %s is not a struct", reqType))
+	}
+	bodyField := findBodyField(structType)
+
+	return func(ctx Context) (*Response, error) {
+		target := reflect.New(structType)
+		if err := bindTyped(ctx, target, bodyField); err != nil {
+			return nil, err
+		}
+		if config.validator != nil {
+			if err := IssuesError(config.validator.Validate(target.Interface())); err != nil {
+				return nil, err
+			}
+		}
+
+		var req Req
+		if reqType.Kind() == reflect.Pointer {
+			req = target.Interface().(Req)
+		} else {
+			req = target.Elem().Interface().(Req)
+		}
+
+		resp, err := fn(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return typedResponse(resp), nil
+	}
+}
+
+// findBodyField returns the index of the field tagged `body`, or nil
+func findBodyField(t reflect.Type) []int {
+	for i := 0; i < t.NumField(); i++ {
+		if _, ok := t.Field(i).Tag.Lookup("body"); ok {
+			return t.Field(i).Index
+		}
+	}
+	return nil
+}
+
+// bindTyped fills the struct target points to from the body, then the path parameters, query and headers
+func bindTyped(ctx Context, target reflect.Value, bodyField []int) error {
+	if ctx.Header("Content-Type") != "" {
+		dest := target.Interface()
+		if bodyField != nil {
+			dest = target.Elem().FieldByIndex(bodyField).Addr().Interface()
+		}
+		if err := Bind(ctx, dest); err != nil {
+			return err
+		}
+		if bodyField == nil {
+			// Fields bound from the path, query and headers must not be settable through the body
+			clearTagged(target.Elem())
+		}
+	}
+
+	req := ctx.Request()
+	query := req.URL.Query()
+	var fields []FieldError
+	sources := []struct {
+		tag    string
+		lookup func(name string) []string
+	}{
+		{"param", func(name string) []string {
+			if value := ctx.Param(name); value != "" {
+				return []string{value}
+			}
+			return nil
+		}},
+		{"query", func(name string) []string { return query[name] }},
+		{"header", func(name string) []string { return req.Header[textproto.CanonicalMIMEHeaderKey(name)] }},
+	}
+	for _, source := range sources {
+		fields = bindTagged(target.Elem(), source.tag, source.lookup, fields)
+	}
+	if len(fields) > 0 {
+		return &ValidationError{Fields: fields}
+	}
+	return nil
+}
+
+// bindTagged sets the fields of v that have tag, collecting conversion failures
+func bindTagged(v reflect.Value, tag string, lookup func(name string) []string, fields []FieldError) []FieldError {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			fields = bindTagged(v.Field(i), tag, lookup, fields)
+			continue
+		}
+		value, ok := field.Tag.Lookup(tag)
+		if !ok || value == "" || value == "-" || !field.IsExported() {
+			continue
+		}
+		// The tag may list several names, as with useheaders; the first one present wins
+		names := strings.Split(value, ",")
+		name := strings.TrimSpace(names[0])
+		var values []string
+		for _, alias := range names {
+			if values = lookup(strings.TrimSpace(alias)); len(values) > 0 {
+				name = strings.TrimSpace(alias)
+				break
+			}
+		}
+		if len(values) == 0 {
+			continue
+		}
+		if err := setField(v.Field(i), values); err != nil {
+			fields = append(fields, FieldError{
+				Path:    tag + "." + name,
+				Code:    "invalid_type",
+				Message: fmt.Sprintf("invalid value %q", values[0]),
+			})
+		}
+	}
+	return fields
+}
+
+// typedTags are the tags of fields bound from outside the body
+var typedTags = []string{"param", "query", "header"}
+
+// clearTagged zeroes the fields of v bound from the path parameters, query or headers
+func clearTagged(v reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			clearTagged(v.Field(i))
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		for _, tag := range typedTags {
+			if name, ok := field.Tag.Lookup(tag); ok && name != "" && name != "-" {
+				v.Field(i).SetZero()
+				break
+			}
+		}
+	}
+}
+
+// typedResponse wraps a typed handler's result in a Response
+func typedResponse(resp any) *Response {
+	if r, ok := resp.(*Response); ok && r != nil {
+		return r
+	}
+
+	status := http.StatusOK
+	var body any = resp
+	if v := reflect.ValueOf(resp); !v.IsValid() || (v.Kind() == reflect.Pointer && v.IsNil()) {
+		status, body = http.StatusNoContent, nil
+	}
+	if coder, ok := resp.(StatusCoder); ok && body != nil {
+		status = coder.StatusCode()
+	}
+
+	response := NewResponse().WithStatus(status).WithBody(body)
+	if setter, ok := resp.(HeaderSetter); ok && body != nil {
+		for name, value := range setter.ResponseHeaders() {
+			response.WithHeader(name, value)
+		}
+	}
+	return response
+}
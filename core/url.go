@@ -0,0 +1,139 @@
+package core
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// URLBuilder builds the URLs of named routes; every App is one
+type URLBuilder interface {
+	URL(name string, params map[string]string, query url.Values) (string, error)
+}
+
+// BuildURL builds the path of the route named name, filling its parameters from params and
+// appending query
+// Parameter values are escaped, and a catch-all parameter may contain slashes, e.g. "docs/intro.md"
+// It returns an error if no route has the name or a parameter is missing or not in the pattern
+func BuildURL(routes []*Route, name string, params map[string]string, query url.Values) (string, error) {
+	var route *Route
+	for _, r := range routes {
+		if r.Name == name {
+			route = r
+			break
+		}
+	}
+	if route == nil {
+		return "", fmt.Errorf("no route named %q", name)
+	}
+
+	path, err := fillPattern(route.Path, params)
+	if err != nil {
+		return "", fmt.Errorf("route %q: %w", name, err)
+	}
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	return path, nil
+}
+
+// fillPattern substitutes params into a route pattern such as "/users/:id" or "/static/*filepath"
+// The fasthttp router's "/users/{id}" and "/static/{filepath:*}" forms are filled the same way
+func fillPattern(pattern string, params map[string]string) (string, error) {
+	used := make(map[string]bool, len(params))
+	segments := strings.Split(pattern, "/")
+	for i, segment := range segments {
+		name, catchAll, ok := patternParam(segment)
+		if !ok {
+			continue
+		}
+		value, ok := params[name]
+		if !ok {
+			return "", fmt.Errorf("missing parameter %q", name)
+		}
+		used[name] = true
+
+		if !catchAll {
+			if value == "" {
+				return "", fmt.Errorf("empty parameter %q", name)
+			}
+			segments[i] = url.PathEscape(value)
+			continue
+		}
+		// A catch-all spans segments, so escape each one and keep the slashes
+		parts := strings.Split(strings.TrimPrefix(value, "/"), "/")
+		for j, part := range parts {
+			parts[j] = url.PathEscape(part)
+		}
+		segments[i] = strings.Join(parts, "/")
+	}
+
+	var unknown []string
+	for name := range params {
+		if !used[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return "", fmt.Errorf("unknown parameter %q", unknown[0])
+	}
+	return strings.Join(segments, "/"), nil
+}
+
+// patternParam reports whether a pattern segment is a parameter, returning its name and
+// whether it is a catch-all: ":id" and "{id}" match one segment, "*path" and "{path:*}" the rest
+func patternParam(segment string) (name string, catchAll bool, ok bool) {
+	switch {
+	case strings.HasPrefix(segment, ":") && len(segment) > 1:
+		return segment[1:], false, true
+	case strings.HasPrefix(segment, "*") && len(segment) > 1:
+		return segment[1:], true, true
+	case strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") && len(segment) > 2:
+		name = segment[1 : len(segment)-1]
+		if base, ok := strings.CutSuffix(name, ":*"); ok {
+			return base, true, true
+		}
+		return name, false, true
+	}
+	return "", false, false
+}
+
+// ContextURL builds the path of the named route of the app serving ctx
+// Adapters implement Context.URL with it
+func ContextURL(ctx Context, name string, params map[string]string, query url.Values) (string, error) {
+	builder, ok := GetValue(ctx, urlBuilderKey)
+	if !ok {
+		return "", fmt.Errorf("no route named %q: the context has no app", name)
+	}
+	return builder.URL(name, params, query)
+}
+
+// ContextAbsoluteURL builds the absolute URL of the named route from the request's scheme and host
+// Adapters implement Context.AbsoluteURL with it
+func ContextAbsoluteURL(ctx Context, name string, params map[string]string, query url.Values) (string, error) {
+	path, err := ContextURL(ctx, name, params, query)
+	if err != nil {
+		return "", err
+	}
+	return RequestOrigin(ctx.Request()) + path, nil
+}
+
+// RequestOrigin returns the scheme and host the request was sent to, e.g. "https://example.com"
+// The scheme is https for TLS connections, or as set by a proxy in X-Forwarded-Proto
+func RequestOrigin(req *http.Request) string {
+	scheme := "http"
+	if req.TLS != nil {
+		scheme = "https"
+	}
+	if proto := strings.ToLower(req.Header.Get("X-Forwarded-Proto")); proto == "http" || proto == "https" {
+		scheme = proto
+	}
+	host := req.Host
+	if host == "" {
+		host = req.Header.Get("Host")
+	}
+	return scheme + "://" + host
+}
@@ -0,0 +1,75 @@
+package core_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/hemant-mann/lumora-go/adapters/fasthttp"
+	"github.com/hemant-mann/lumora-go/adapters/gin"
+	"github.com/hemant-mann/lumora-go/adapters/nethttp"
+	"github.com/hemant-mann/lumora-go/core"
+)
+
+func TestBuildURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		params  map[string]string
+		query   url.Values
+		want    string
+		wantErr bool
+	}{
+		{name: "colon param", pattern: "/users/:id", params: map[string]string{"id": "42"}, want: "/users/42"},
+		{name: "brace param", pattern: "/users/{id}", params: map[string]string{"id": "42"}, want: "/users/42"},
+		{name: "star catch-all", pattern: "/files/*path", params: map[string]string{"path": "docs/intro.md"}, want: "/files/docs/intro.md"},
+		{name: "brace catch-all", pattern: "/files/{path:*}", params: map[string]string{"path": "docs/intro.md"}, want: "/files/docs/intro.md"},
+		{name: "escaped value", pattern: "/users/{id}", params: map[string]string{"id": "a b/c"}, want: "/users/a%20b%2Fc"},
+		{name: "query", pattern: "/users/:id", params: map[string]string{"id": "1"}, query: url.Values{"tab": {"posts"}}, want: "/users/1?tab=posts"},
+		{name: "missing param", pattern: "/users/{id}", params: nil, wantErr: true},
+		{name: "empty param", pattern: "/users/:id", params: map[string]string{"id": ""}, wantErr: true},
+		{name: "unknown param", pattern: "/users/{id}", params: map[string]string{"id": "1", "extra": "x"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			routes := []*core.Route{core.NewRoute("GET", tt.pattern).WithName("route")}
+			got, err := core.BuildURL(routes, "route", tt.params, tt.query)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("BuildURL(%q) = %q, want an error", tt.pattern, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("BuildURL(%q): %v", tt.pattern, err)
+			}
+			if got != tt.want {
+				t.Errorf("BuildURL(%q) = %q, want %q", tt.pattern, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAppURL(t *testing.T) {
+	handler := func(ctx core.Context) (*core.Response, error) { return nil, nil }
+	apps := map[string]struct {
+		app      core.App
+		patterns []string
+	}{
+		"nethttp":  {nethttp.New(), []string{"/users/:id", "/files/*path"}},
+		"gin":      {gin.New(), []string{"/users/:id", "/files/*path"}},
+		"fasthttp": {fasthttp.New(), []string{"/users/{id}", "/files/{path:*}"}},
+	}
+	for name, tt := range apps {
+		t.Run(name, func(t *testing.T) {
+			tt.app.Get(tt.patterns[0], handler).WithName("user")
+			tt.app.Get(tt.patterns[1], handler).WithName("file")
+
+			if got, err := tt.app.URL("user", map[string]string{"id": "7"}, nil); err != nil || got != "/users/7" {
+				t.Errorf("URL(user) = %q, %v; want /users/7", got, err)
+			}
+			if got, err := tt.app.URL("file", map[string]string{"path": "a/b.txt"}, nil); err != nil || got != "/files/a/b.txt" {
+				t.Errorf("URL(file) = %q, %v; want /files/a/b.txt", got, err)
+			}
+		})
+	}
+}
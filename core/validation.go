@@ -0,0 +1,77 @@
+package core
+
+import (
+	"errors"
+	"strings"
+
+	z "github.com/Oudwins/zog"
+)
+
+// FieldError describes why a single field failed validation
+type FieldError struct {
+	// Path is the dotted path of the field, e.g. "address.city"; empty for the whole input
+	Path    string         `json:"path"`
+	Code    string         `json:"code"`
+	Message string         `json:"message"`
+	Params  map[string]any `json:"params,omitempty"`
+}
+
+// ValidationError is returned by the validation hooks when the input does not match the schema
+// It carries one FieldError per issue so error handlers can report every field at once
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Fields) == 0 {
+		return "validation failed"
+	}
+	parts := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		if field.Path == "" {
+			parts[i] = field.Message
+		} else {
+			parts[i] = field.Path + ": " + field.Message
+		}
+	}
+	return "validation failed: " + strings.Join(parts, "; ")
+}
+
+// NewValidationError creates a ValidationError from zog issues
+func NewValidationError(issues z.ZogIssueList) *ValidationError {
+	fields := make([]FieldError, 0, len(issues))
+	for _, issue := range issues {
+		if issue == nil {
+			continue
+		}
+		message := issue.Message
+		if message == "" && issue.Err != nil {
+			message = issue.Err.Error()
+		}
+		fields = append(fields, FieldError{
+			Path:    strings.Join(issue.Path, "."),
+			Code:    string(issue.Code),
+			Message: message,
+			Params:  issue.Params,
+		})
+	}
+	return &ValidationError{Fields: fields}
+}
+
+// IssuesError returns the zog issues as a *ValidationError, or nil when there are none,
+// so hooks can return it as is and errorhandler renders the issues per field
+func IssuesError(issues z.ZogIssueList) error {
+	if len(issues) == 0 {
+		return nil
+	}
+	return NewValidationError(issues)
+}
+
+// GetValidationError extracts a ValidationError from an error
+func GetValidationError(err error) *ValidationError {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return validationErr
+	}
+	return nil
+}
@@ -7,9 +7,8 @@ import (
 	"github.com/hemant-mann/lumora-go/middleware/cors"
 	"github.com/hemant-mann/lumora-go/middleware/errorhandler"
 	"github.com/hemant-mann/lumora-go/middleware/logging"
-	"github.com/hemant-mann/lumora-go/middleware/useheaders"
-	"github.com/hemant-mann/lumora-go/middleware/usejsonbody"
 	"github.com/hemant-mann/lumora-go/middleware/useservices"
+	"github.com/hemant-mann/lumora-go/openapi"
 )
 
 // Example services
@@ -55,6 +54,46 @@ type User struct {
 	Age   int    `json:"age"`
 }
 
+// Typed handler request and response types
+type UserRequest struct {
+	ID string `param:"id"`
+}
+
+type ProtectedUserRequest struct {
+	ID    string `param:"id"`
+	Token string `header:"Authorization"`
+}
+
+type UserResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Auth string `json:"auth,omitempty"`
+}
+
+type HomeResponse struct {
+	Message string `json:"message"`
+	Version string `json:"version"`
+}
+
+type CreatedUserResponse struct {
+	Message string `json:"message"`
+	User    User   `json:"user"`
+}
+
+// StatusCode sends created users with 201 instead of 200
+func (CreatedUserResponse) StatusCode() int { return 201 }
+
+type AuthRequest struct {
+	Authorization string `header:"Authorization"`
+	APIKey        string `header:"X-Api-Key"`
+}
+
+type AccessResponse struct {
+	Message   string `json:"message"`
+	HasToken  bool   `json:"hasToken"`
+	HasAPIKey bool   `json:"hasApiKey"`
+}
+
 func main() {
 	app := gin.New()
 
@@ -69,79 +108,51 @@ func main() {
 	)
 
 	// Define routes with route-specific services (like Lumora JS!)
-	app.Get("/", func(ctx core.Context) (*core.Response, error) {
-		resp := core.NewResponse().
-			WithStatus(200).
-			WithBody(map[string]any{
-				"message": "Hello, World!",
-				"version": "1.0.0",
-			})
-		return resp, nil
-	})
+	app.Get("/", core.Typed(func(ctx core.Context, req struct{}) (HomeResponse, error) {
+		return HomeResponse{Message: "Hello, World!", Version: "1.0.0"}, nil
+	}))
 
-	// Route with route-specific services - similar to Lumora JS useServices
+	// Typed handler: the path parameter is bound into the request struct and the
+	// returned value is encoded as the response body
 	app.Get("/users/:id",
-		func(ctx core.Context) (*core.Response, error) {
-			id := ctx.Param("id")
-
+		core.Typed(func(ctx core.Context, req UserRequest) (UserResponse, error) {
 			// Access route-specific service
 			userService := ctx.MustService("userService").(*UserService)
-			name, exists := userService.GetUser(id)
-
+			name, exists := userService.GetUser(req.ID)
 			if !exists {
-				resp := core.NewResponse().
-					WithStatus(404).
-					WithBody(map[string]string{"error": "User not found"})
-				return resp, nil
+				return UserResponse{}, core.NotFound("User not found")
 			}
-
-			resp := core.NewResponse().
-				WithStatus(200).
-				WithBody(map[string]any{
-					"id":   id,
-					"name": name,
-				})
-			return resp, nil
-		},
+			return UserResponse{ID: req.ID, Name: name}, nil
+		}),
 		useservices.UseServices(map[string]any{
 			"userService": NewUserService(),
 		}),
-	)
+	).
+		WithName("user").
+		WithSummary("Get a user").
+		WithTags("users").
+		WithRequest(UserRequest{}).
+		WithResponse(200, "The user", UserResponse{}).
+		WithResponse(404, "User not found", nil)
 
 	// Route with multiple route-specific services
 	app.Get("/protected/:id",
-		func(ctx core.Context) (*core.Response, error) {
+		core.Typed(func(ctx core.Context, req ProtectedUserRequest) (UserResponse, error) {
 			// Access route-specific services
 			userService := ctx.MustService("userService").(*UserService)
 			authService := ctx.MustService("authService").(*AuthService)
 
 			// Check auth token
-			token := ctx.Header("Authorization")
-			if !authService.ValidateToken(token) {
-				resp := core.NewResponse().
-					WithStatus(401).
-					WithBody(map[string]string{"error": "Unauthorized"})
-				return resp, nil
+			if !authService.ValidateToken(req.Token) {
+				return UserResponse{}, core.Unauthorized("Unauthorized")
 			}
 
-			id := ctx.Param("id")
-			name, exists := userService.GetUser(id)
+			name, exists := userService.GetUser(req.ID)
 			if !exists {
-				resp := core.NewResponse().
-					WithStatus(404).
-					WithBody(map[string]string{"error": "User not found"})
-				return resp, nil
+				return UserResponse{}, core.NotFound("User not found")
 			}
-
-			resp := core.NewResponse().
-				WithStatus(200).
-				WithBody(map[string]any{
-					"id":   id,
-					"name": name,
-					"auth": "validated",
-				})
-			return resp, nil
-		},
+			return UserResponse{ID: req.ID, Name: name, Auth: "validated"}, nil
+		}),
 		useservices.UseServices(map[string]any{
 			"userService": NewUserService(),
 			"authService": NewAuthService(), // Overrides app-level authService for this route
@@ -155,32 +166,21 @@ func main() {
 	})
 
 	// Using UseService helper for single service
+	// The body is decoded into User and validated with userSchema before the handler runs
 	app.Post("/users",
-		func(ctx core.Context) (*core.Response, error) {
-			user := usejsonbody.GetJsonBody(ctx).(*User)
-			resp := core.NewResponse().
-				WithStatus(201).
-				WithBody(map[string]any{
-					"message": "User created",
-					"user":    user,
-				})
-			return resp, nil
-		},
+		core.Typed(func(ctx core.Context, user User) (CreatedUserResponse, error) {
+			return CreatedUserResponse{Message: "User created", User: user}, nil
+		}, core.WithValidator(userSchema)),
 		useservices.UseService("userService", NewUserService()),
-		usejsonbody.UseJsonBody(userSchema, &User{}),
 	)
 
-	// Example with plain text response
-	app.Get("/text", func(ctx core.Context) (*core.Response, error) {
-		resp := core.NewResponse().
-			WithStatus(200).
-			WithHeader("Content-Type", "text/plain").
-			WithBody("This is a plain text response")
-		return resp, nil
-	})
+	// Example with plain text response: a string body is sent as text/plain
+	app.Get("/text", core.Typed(func(ctx core.Context, req struct{}) (string, error) {
+		return "This is a plain text response", nil
+	}))
 
-	// Example with cookies
-	app.Get("/cookie", func(ctx core.Context) (*core.Response, error) {
+	// Example with cookies: returning a *core.Response gives full control over the response
+	app.Get("/cookie", core.Typed(func(ctx core.Context, req struct{}) (*core.Response, error) {
 		resp := core.NewResponse().
 			WithStatus(200).
 			WithCookie(core.Cookie{
@@ -192,36 +192,29 @@ func main() {
 			}).
 			WithBody(map[string]string{"message": "Cookie set"})
 		return resp, nil
-	})
+	}))
 
-	app.Get("/error", func(ctx core.Context) (*core.Response, error) {
+	app.Get("/error", core.Typed(func(ctx core.Context, req struct{}) (*HomeResponse, error) {
 		return nil, core.NewError(500, "This is an error example")
-	})
+	}))
 
-	// Example with useHeaders middleware
-	type AuthHeaders struct {
-		Authorization string `json:"authorization"`
-		APIKey        string `json:"x-api-key,omitempty"`
-	}
-
-	var authHeadersSchema = z.Struct(z.Shape{
+	// Example with headers bound by a typed handler
+	var authRequestSchema = z.Struct(z.Shape{
 		"authorization": z.String().Min(1),
-		"x-api-key":     z.String().Optional(),
 	})
 
 	app.Get("/api/protected",
-		func(ctx core.Context) (*core.Response, error) {
-			headers := useheaders.GetHeaders(ctx).(*AuthHeaders)
-			resp := core.NewResponse().
-				WithStatus(200).
-				WithBody(map[string]any{
-					"message":  "Access granted via Gin",
-					"hasToken": headers.Authorization != "",
-				})
-			return resp, nil
-		},
-		useheaders.UseHeaders(authHeadersSchema, &AuthHeaders{}),
+		core.Typed(func(ctx core.Context, req AuthRequest) (AccessResponse, error) {
+			return AccessResponse{
+				Message:   "Access granted via Gin",
+				HasToken:  req.Authorization != "",
+				HasAPIKey: req.APIKey != "",
+			}, nil
+		}, core.WithValidator(authRequestSchema)),
 	)
 
+	// Serve the OpenAPI document at /openapi.json and Swagger UI at /docs
+	openapi.Mount(app, nil)
+
 	app.Start(":8081")
 }
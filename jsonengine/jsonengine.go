@@ -0,0 +1,61 @@
+// Package jsonengine provides faster core.JSONEngine implementations
+//
+//	app.SetJSONEngine(jsonengine.Sonic())
+//	app.SetJSONEngine(jsonengine.GoJSON())
+package jsonengine
+
+import (
+	"io"
+
+	"github.com/bytedance/sonic"
+	gojson "github.com/goccy/go-json"
+	"github.com/hemant-mann/lumora-go/core"
+)
+
+// sonicEngine adapts a sonic.API to core.JSONEngine
+type sonicEngine struct {
+	api sonic.API
+}
+
+// Sonic returns a bytedance/sonic engine configured to match encoding/json output
+// On platforms or Go versions sonic does not support it falls back to encoding/json
+func Sonic() core.JSONEngine {
+	return SonicWithConfig(sonic.ConfigStd)
+}
+
+// SonicWithConfig returns a sonic engine using api, e.g. sonic.ConfigFastest
+func SonicWithConfig(api sonic.API) core.JSONEngine {
+	return sonicEngine{api: api}
+}
+
+func (e sonicEngine) Marshal(v any) ([]byte, error) { return e.api.Marshal(v) }
+
+func (e sonicEngine) Unmarshal(data []byte, v any) error {
+	return core.WrapJSONDecodeError(e.api.Unmarshal(data, v))
+}
+
+func (e sonicEngine) NewEncoder(w io.Writer) core.JSONEncoder { return e.api.NewEncoder(w) }
+
+func (e sonicEngine) NewDecoder(r io.Reader) core.JSONDecoder {
+	return core.WrapJSONDecoder(e.api.NewDecoder(r))
+}
+
+// goJSONEngine uses goccy/go-json
+type goJSONEngine struct{}
+
+// GoJSON returns a goccy/go-json engine, a drop-in replacement for encoding/json
+func GoJSON() core.JSONEngine {
+	return goJSONEngine{}
+}
+
+func (goJSONEngine) Marshal(v any) ([]byte, error) { return gojson.Marshal(v) }
+
+func (goJSONEngine) Unmarshal(data []byte, v any) error {
+	return core.WrapJSONDecodeError(gojson.Unmarshal(data, v))
+}
+
+func (goJSONEngine) NewEncoder(w io.Writer) core.JSONEncoder { return gojson.NewEncoder(w) }
+
+func (goJSONEngine) NewDecoder(r io.Reader) core.JSONDecoder {
+	return core.WrapJSONDecoder(gojson.NewDecoder(r))
+}
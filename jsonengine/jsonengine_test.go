@@ -0,0 +1,174 @@
+package jsonengine
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/hemant-mann/lumora-go/core"
+)
+
+// Payloads modelled on typical API responses: a single resource and a paginated list
+
+type address struct {
+	Street  string `json:"street"`
+	City    string `json:"city"`
+	Country string `json:"country"`
+	Zip     string `json:"zip"`
+}
+
+type lineItem struct {
+	SKU      string            `json:"sku"`
+	Name     string            `json:"name"`
+	Quantity int               `json:"quantity"`
+	Price    float64           `json:"price"`
+	Tags     []string          `json:"tags"`
+	Attrs    map[string]string `json:"attrs"`
+}
+
+type order struct {
+	ID        string     `json:"id"`
+	Customer  string     `json:"customer"`
+	Email     string     `json:"email"`
+	Status    string     `json:"status"`
+	CreatedAt time.Time  `json:"createdAt"`
+	Shipping  address    `json:"shipping"`
+	Items     []lineItem `json:"items"`
+	Total     float64    `json:"total"`
+	Paid      bool       `json:"paid"`
+	Notes     *string    `json:"notes,omitempty"`
+}
+
+type orderPage struct {
+	Data       []order `json:"data"`
+	Page       int     `json:"page"`
+	PerPage    int     `json:"perPage"`
+	TotalCount int     `json:"totalCount"`
+}
+
+func newOrder(i int) order {
+	items := make([]lineItem, 5)
+	for j := range items {
+		items[j] = lineItem{
+			SKU:      fmt.Sprintf("SKU-%05d-%d", i, j),
+			Name:     "Stainless steel water bottle, 750ml",
+			Quantity: j + 1,
+			Price:    19.99 + float64(j),
+			Tags:     []string{"outdoor", "kitchen", "gift"},
+			Attrs:    map[string]string{"color": "blue", "size": "L"},
+		}
+	}
+	return order{
+		ID:        fmt.Sprintf("ord_%08d", i),
+		Customer:  "Jane Doe",
+		Email:     "jane.doe@example.com",
+		Status:    "shipped",
+		CreatedAt: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		Shipping:  address{Street: "221B Baker Street", City: "London", Country: "GB", Zip: "NW1 6XE"},
+		Items:     items,
+		Total:     149.95,
+		Paid:      true,
+	}
+}
+
+func newOrderPage(n int) orderPage {
+	page := orderPage{Page: 1, PerPage: n, TotalCount: 10 * n}
+	for i := 0; i < n; i++ {
+		page.Data = append(page.Data, newOrder(i))
+	}
+	return page
+}
+
+var engines = []struct {
+	name   string
+	engine core.JSONEngine
+}{
+	{"std", core.StdJSON{}},
+	{"sonic", Sonic()},
+	{"gojson", GoJSON()},
+}
+
+var payloads = []struct {
+	name  string
+	value any
+	new   func() any
+}{
+	{"order", newOrder(1), func() any { return new(order) }},
+	{"page100", newOrderPage(100), func() any { return new(orderPage) }},
+}
+
+func BenchmarkEncode(b *testing.B) {
+	for _, payload := range payloads {
+		for _, e := range engines {
+			b.Run(payload.name+"/"+e.name, func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					if err := e.engine.NewEncoder(io.Discard).Encode(payload.value); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkDecode(b *testing.B) {
+	for _, payload := range payloads {
+		data, err := core.StdJSON{}.Marshal(payload.value)
+		if err != nil {
+			b.Fatal(err)
+		}
+		for _, e := range engines {
+			b.Run(payload.name+"/"+e.name, func(b *testing.B) {
+				b.ReportAllocs()
+				b.SetBytes(int64(len(data)))
+				for i := 0; i < b.N; i++ {
+					if err := e.engine.NewDecoder(bytes.NewReader(data)).Decode(payload.new()); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkUnmarshalMap(b *testing.B) {
+	// Body hooks decode into map[string]any before validating
+	data, err := core.StdJSON{}.Marshal(newOrder(1))
+	if err != nil {
+		b.Fatal(err)
+	}
+	for _, e := range engines {
+		b.Run(e.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				var m map[string]any
+				if err := e.engine.Unmarshal(data, &m); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// TestEnginesRoundTrip checks every engine produces output the others can read back
+func TestEnginesRoundTrip(t *testing.T) {
+	want := newOrder(7)
+	for _, encoder := range engines {
+		data, err := encoder.engine.Marshal(want)
+		if err != nil {
+			t.Fatalf("%s: marshal: %v", encoder.name, err)
+		}
+		for _, decoder := range engines {
+			var got order
+			if err := decoder.engine.Unmarshal(data, &got); err != nil {
+				t.Fatalf("%s -> %s: unmarshal: %v", encoder.name, decoder.name, err)
+			}
+			if got.ID != want.ID || len(got.Items) != len(want.Items) || !got.CreatedAt.Equal(want.CreatedAt) || got.Items[2].Attrs["color"] != "blue" {
+				t.Fatalf("%s -> %s: round trip mismatch: %+v", encoder.name, decoder.name, got)
+			}
+		}
+	}
+}
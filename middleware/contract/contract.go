@@ -0,0 +1,233 @@
+package contract
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"reflect"
+	"strings"
+	"sync"
+
+	z "github.com/Oudwins/zog"
+	"github.com/hemant-mann/lumora-go/core"
+	"github.com/hemant-mann/lumora-go/middleware/logging"
+	"github.com/hemant-mann/lumora-go/openapi"
+)
+
+// Options represents response contract validation options
+type Options struct {
+	// Enabled turns validation on; when false New returns a middleware that adds nothing to the chain,
+	// so production builds pay nothing for it
+	Enabled bool
+	// FailOnViolation replaces a violating response with a 500 listing the violations
+	// When false violations are only logged and the response is sent unchanged
+	FailOnViolation bool
+	// Strict reports object properties the schema doesn't declare, such as a field added to a response type
+	// but not to its documented schema
+	Strict bool
+	// Logger receives the violations
+	Logger logging.Logger
+}
+
+// DefaultOptions returns default contract options: enabled, strict, and logging violations
+func DefaultOptions() *Options {
+	return &Options{
+		Enabled: true,
+		Strict:  true,
+		Logger:  &logging.DefaultLogger{},
+	}
+}
+
+// New creates a middleware that checks responses against the responses documented on their route
+// with Route.WithResponse, or loaded from an OpenAPI document by openapi's Spec.Mount
+// Routes without documented responses are not checked. The status code must be documented, and a JSON
+// body must match the documented schema (a zog schema, an openapi.Schema or a Go type)
+// Register it inside errorhandler so handler errors are rendered after the check; errors are not checked
+func New(options *Options) core.Middleware {
+	if options == nil {
+		options = DefaultOptions()
+	}
+	if !options.Enabled {
+		return func(next core.Handler) core.Handler {
+			return next
+		}
+	}
+
+	return func(next core.Handler) core.Handler {
+		// Keep the route this handler serves; its documentation is read at request time,
+		// so responses documented after registration are seen too
+		var route *core.Route
+		core.DescribeRoute(func(r *core.Route) {
+			route = r
+		})
+		if route == nil {
+			return next
+		}
+		schemas := &schemaCache{strict: options.Strict}
+
+		return func(ctx core.Context) (*core.Response, error) {
+			resp, err := next(ctx)
+			if err != nil || resp == nil || len(route.Responses) == 0 {
+				return resp, err
+			}
+
+			violations := check(ctx, route, resp, schemas)
+			if len(violations) == 0 {
+				return resp, nil
+			}
+			if options.Logger != nil {
+				options.Logger.Log("WARN", "Response violates the route contract", map[string]any{
+					"method":     route.Method,
+					"route":      route.Path,
+					"status":     resp.StatusCode,
+					"violations": violations,
+				})
+			}
+			if options.FailOnViolation {
+				return nil, core.InternalServerError("Response violates the API contract").
+					WithErrorCode("contract_violation").
+					WithDetail("violations", violations)
+			}
+			return resp, nil
+		}
+	}
+}
+
+// Dev creates a contract middleware that fails violating responses, for development and test runs
+func Dev() core.Middleware {
+	options := DefaultOptions()
+	options.FailOnViolation = true
+	return New(options)
+}
+
+// check returns the ways resp differs from the route's documented responses
+func check(ctx core.Context, route *core.Route, resp *core.Response, schemas *schemaCache) []core.FieldError {
+	documented, ok := route.Responses[resp.StatusCode]
+	if !ok {
+		return []core.FieldError{{
+			Path:    "status",
+			Code:    "undocumented_status",
+			Message: fmt.Sprintf("status %d is not documented", resp.StatusCode),
+			Params:  map[string]any{"status": resp.StatusCode},
+		}}
+	}
+
+	if documented.Body == nil {
+		if resp.Body != nil {
+			return []core.FieldError{{Path: "body", Code: "unexpected_body", Message: "the response is documented without a body"}}
+		}
+		return nil
+	}
+	if resp.Body == nil {
+		return []core.FieldError{{Path: "body", Code: "required", Message: "is required"}}
+	}
+	if !isJSONBody(resp) {
+		// Only JSON bodies can be compared with a schema
+		return nil
+	}
+
+	engine := core.GetJSONEngine(ctx)
+	data, err := engine.Marshal(resp.Body)
+	if err != nil {
+		return []core.FieldError{{Path: "body", Code: "invalid_json", Message: err.Error()}}
+	}
+	var value any
+	if err := engine.Unmarshal(data, &value); err != nil {
+		return []core.FieldError{{Path: "body", Code: "invalid_json", Message: err.Error()}}
+	}
+
+	violations := schemas.get(documented, reflect.TypeOf(resp.Body)).Validate(value)
+	for i := range violations {
+		violations[i].Path = strings.TrimSuffix("body."+violations[i].Path, ".")
+	}
+	return violations
+}
+
+// isJSONBody reports whether the response body is encoded as JSON
+// Raw bodies (bytes, strings with a content type, readers) are skipped
+func isJSONBody(resp *core.Response) bool {
+	switch resp.Body.(type) {
+	case []byte, io.Reader:
+		return false
+	}
+	contentType := resp.Headers["Content-Type"]
+	if contentType == "" {
+		return true
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// schemaCache converts each documented response to JSON Schema once per body type
+type schemaCache struct {
+	strict  bool
+	schemas sync.Map
+}
+
+type schemaKey struct {
+	response *core.RouteResponse
+	body     reflect.Type
+}
+
+func (c *schemaCache) get(documented *core.RouteResponse, body reflect.Type) openapi.Schema {
+	key := schemaKey{documented, body}
+	if schema, ok := c.schemas.Load(key); ok {
+		return schema.(openapi.Schema)
+	}
+
+	var schema openapi.Schema
+	switch declared := documented.Body.(type) {
+	case openapi.Schema:
+		schema = declared
+	case z.ZogSchema:
+		// The body's type names the properties the way they are encoded
+		schema = openapi.ZogSchema(declared, body)
+	default:
+		schema = openapi.TypeSchema(reflect.TypeOf(declared))
+	}
+	if c.strict {
+		schema = closeObjects(schema)
+	}
+	c.schemas.Store(key, schema)
+	return schema
+}
+
+// closeObjects copies schema, disallowing properties that objects with declared properties don't list
+// Subschemas of allOf, anyOf and oneOf are left open, since each describes only part of the object
+func closeObjects(schema openapi.Schema) openapi.Schema {
+	out := make(openapi.Schema, len(schema))
+	for key, value := range schema {
+		switch key {
+		case "properties":
+			properties := make(openapi.Schema)
+			for name, property := range asSchema(value) {
+				properties[name] = closeObjects(asSchema(property))
+			}
+			out[key] = properties
+		case "items", "additionalProperties":
+			if sub := asSchema(value); sub != nil {
+				out[key] = closeObjects(sub)
+			} else {
+				out[key] = value
+			}
+		default:
+			out[key] = value
+		}
+	}
+	if _, ok := out["properties"]; ok {
+		if _, ok := out["additionalProperties"]; !ok {
+			out["additionalProperties"] = false
+		}
+	}
+	return out
+}
+
+func asSchema(value any) openapi.Schema {
+	switch v := value.(type) {
+	case openapi.Schema:
+		return v
+	case map[string]any:
+		return v
+	}
+	return nil
+}
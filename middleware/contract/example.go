@@ -0,0 +1,31 @@
+package contract
+
+// Example usage of the contract middleware:
+//
+// import (
+//     "os"
+//
+//     "github.com/hemant-mann/lumora-go/core"
+//     "github.com/hemant-mann/lumora-go/middleware/contract"
+//     "github.com/hemant-mann/lumora-go/middleware/errorhandler"
+// )
+//
+// type UserResponse struct {
+//     ID   string `json:"id"`
+//     Name string `json:"name"`
+// }
+//
+// // Check responses in development and tests only; disabled, the middleware is not even in the chain
+// options := contract.DefaultOptions()
+// options.Enabled = os.Getenv("APP_ENV") != "production"
+// options.FailOnViolation = os.Getenv("APP_ENV") == "test"
+//
+// // Register it after errorhandler so it sees handler results before errors are rendered
+// app.Use(errorhandler.Simple(), contract.New(options))
+//
+// app.Get("/users/:id", getUser).
+//     WithResponse(200, "The user", UserResponse{}).
+//     WithResponse(404, "User not found", nil)
+//
+// // A 200 whose body has a field UserResponse doesn't declare, or a 201 from this route,
+// // is logged, or turned into a 500 listing the violations when FailOnViolation is set
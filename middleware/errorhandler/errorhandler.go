@@ -2,36 +2,73 @@ package errorhandler
 
 import (
 	"github.com/hemant-mann/lumora-go/core"
+	"github.com/hemant-mann/lumora-go/middleware/logging"
 )
 
 // Options represents error handler configuration options
 type Options struct {
 	// Handler is a custom error handler function that returns a Response
+	// When nil, errors are rendered as {"error": message}, or as problem details if ProblemDetails is set
 	Handler func(ctx core.Context, err error) (*core.Response, error)
-	// LogErrors determines if errors should be logged
+	// LogErrors logs errors that produce a 5xx response through Logger
 	LogErrors bool
+	// Logger receives the logged errors
+	Logger logging.Logger
+	// Registry maps errors that aren't core.Error values, such as context.DeadlineExceeded, to HTTP errors
+	// The handler receives the mapped error
+	Registry *Registry
+	// ProblemDetails renders errors as RFC 9457 application/problem+json
+	ProblemDetails bool
+	// Dev adds the internal error to problem details, with the stack where a core.Error was created
+	// Leave it off in production, where unexpected errors only show a generic message
+	Dev bool
+	// RequestIDHeader names the request header reported as the problem's requestId
+	RequestIDHeader string
 }
 
 // DefaultOptions returns default error handler options
 func DefaultOptions() *Options {
 	return &Options{
-		Handler:   defaultErrorHandler,
-		LogErrors: true,
+		LogErrors:       true,
+		Logger:          &logging.DefaultLogger{},
+		Registry:        DefaultRegistry(),
+		RequestIDHeader: "X-Request-ID",
 	}
 }
 
+// ProblemOptions returns default options with problem details enabled
+func ProblemOptions() *Options {
+	options := DefaultOptions()
+	options.ProblemDetails = true
+	return options
+}
+
 // New creates a new error handler middleware
 func New(options *Options) core.Middleware {
 	if options == nil {
 		options = DefaultOptions()
 	}
+	handler := options.Handler
+	if handler == nil {
+		if options.ProblemDetails {
+			handler = problemHandler(options)
+		} else {
+			handler = defaultErrorHandler
+		}
+	}
 	
 	return func(next core.Handler) core.Handler {
 		return func(ctx core.Context) (*core.Response, error) {
 			resp, err := next(ctx)
 			
 			if err != nil {
-				return options.Handler(ctx, err)
+				if options.Registry != nil {
+					err = options.Registry.Resolve(err)
+				}
+				if options.LogErrors {
+					logError(ctx, options, err)
+				}
+				return handler(ctx, err)
 			}
 			
 			return resp, nil
@@ -41,13 +78,31 @@ func New(options *Options) core.Middleware {
 
 // defaultErrorHandler handles errors by checking if they're HTTP errors
 func defaultErrorHandler(ctx core.Context, err error) (*core.Response, error) {
+	// Validation errors list every failing field so clients can highlight them
+	if validationErr := core.GetValidationError(err); validationErr != nil {
+		resp := core.NewResponse().
+			WithStatus(400).
+			WithBody(map[string]any{
+				"error":  "Validation failed",
+				"fields": validationErr.Fields,
+			})
+		return resp, nil
+	}
+
 	// Check if it's an HTTP error
 	if httpErr := core.GetHTTPError(err); httpErr != nil {
-		// Return error response
+		// Return error response with the public message only; the wrapped cause stays internal
+		body := map[string]any{"error": httpErr.PublicMessage()}
+		if httpErr.ErrorCode != "" {
+			body["code"] = httpErr.ErrorCode
+		}
+		if len(httpErr.Details) > 0 {
+			body["details"] = httpErr.Details
+		}
 		resp := core.NewResponse().
 			WithStatus(httpErr.Code).
-			WithBody(map[string]string{"error": httpErr.Message})
-		return resp, nil
+			WithBody(body)
+		return withErrorHeaders(resp, httpErr), nil
 	}
 	
 	// Default to 500 Internal Server Error
@@ -57,8 +112,49 @@ func defaultErrorHandler(ctx core.Context, err error) (*core.Response, error) {
 	return resp, nil
 }
 
+// logError logs server errors with the request they happened in
+// Client errors are expected and not logged
+func logError(ctx core.Context, options *Options, err error) {
+	status, _ := core.PublicError(err)
+	if status < 500 {
+		return
+	}
+	// Options built before Logger existed leave it nil; they still expect errors logged
+	logger := options.Logger
+	if logger == nil {
+		logger = &logging.DefaultLogger{}
+	}
+	req := ctx.Request()
+	fields := map[string]any{
+		"method": req.Method,
+		"path":   req.URL.Path,
+		"remote": req.RemoteAddr,
+		"status": status,
+		"error":  err.Error(),
+	}
+	if options.RequestIDHeader != "" {
+		if requestID := ctx.Header(options.RequestIDHeader); requestID != "" {
+			fields["request_id"] = requestID
+		}
+	}
+	logger.Log("ERROR", "Request error", fields)
+}
+
+// withErrorHeaders copies the headers attached to an HTTP error onto its response
+func withErrorHeaders(resp *core.Response, httpErr *core.Error) *core.Response {
+	for name, value := range httpErr.Headers {
+		resp.WithHeader(name, value)
+	}
+	return resp
+}
+
 // Simple creates a simple error handler middleware with default options
 func Simple() core.Middleware {
 	return New(DefaultOptions())
 }
 
+// Problem creates an error handler middleware that renders RFC 9457 problem details
+func Problem() core.Middleware {
+	return New(ProblemOptions())
+}
+
@@ -0,0 +1,136 @@
+package errorhandler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hemant-mann/lumora-go/core"
+)
+
+// problemTypes are the media types problem details can be rendered as, preferred first
+var problemTypes = []string{"application/problem+json", "application/json", "text/plain"}
+
+// problemHandler renders errors as RFC 9457 problem details
+func problemHandler(options *Options) func(ctx core.Context, err error) (*core.Response, error) {
+	return func(ctx core.Context, err error) (*core.Response, error) {
+		resp := problemResponse(ctx, newProblem(ctx, options, err))
+		if httpErr := core.GetHTTPError(err); httpErr != nil {
+			withErrorHeaders(resp, httpErr)
+		}
+		return resp, nil
+	}
+}
+
+// newProblem builds the problem object for err
+// Details become extension members, which never replace the standard members
+func newProblem(ctx core.Context, options *Options, err error) map[string]any {
+	problem := map[string]any{}
+	status := http.StatusInternalServerError
+	var title, detail string
+
+	if validationErr := core.GetValidationError(err); validationErr != nil {
+		status = http.StatusBadRequest
+		detail = "The request did not pass validation"
+		problem["errors"] = validationErr.Fields
+	} else if httpErr := core.GetHTTPError(err); httpErr != nil {
+		status = httpErr.Code
+		for name, value := range httpErr.Details {
+			problem[name] = value
+		}
+		if httpErr.ErrorCode != "" {
+			problem["code"] = httpErr.ErrorCode
+		}
+		if httpErr.Type != "" {
+			problem["type"] = httpErr.Type
+		}
+		if httpErr.Instance != "" {
+			problem["instance"] = httpErr.Instance
+		}
+		title = httpErr.Title
+		if title == "" && http.StatusText(status) == "" {
+			// Non-standard statuses such as 499 have no status text
+			title = httpErr.PublicMessage()
+		}
+		detail = httpErr.Detail
+		if detail == "" {
+			detail = httpErr.PublicMessage()
+		}
+	} else {
+		// Unexpected errors may contain internals, so production only gets a generic message
+		detail = "An unexpected error occurred"
+	}
+
+	if title == "" {
+		title = http.StatusText(status)
+	}
+	problem["type"] = stringOr(problem["type"], "about:blank")
+	problem["title"] = title
+	problem["status"] = status
+	if detail != "" && detail != title {
+		problem["detail"] = detail
+	}
+	problem["instance"] = stringOr(problem["instance"], ctx.Request().URL.Path)
+
+	if options.RequestIDHeader != "" {
+		if requestID := ctx.Header(options.RequestIDHeader); requestID != "" {
+			problem["requestId"] = requestID
+		}
+	}
+	if traceID := traceIDFromParent(ctx.Header("traceparent")); traceID != "" {
+		problem["traceId"] = traceID
+	}
+
+	if options.Dev {
+		problem["error"] = err.Error()
+		// The renderer's own stack would only show the middleware, so report where the error was created
+		if httpErr := core.GetHTTPError(err); httpErr != nil {
+			if stack := httpErr.StackTrace(); stack != nil {
+				problem["stack"] = stack
+			}
+		}
+	}
+	return problem
+}
+
+// problemResponse renders the problem in the format the client accepts
+// Clients that accept none of the formats still get problem+json rather than a 406
+func problemResponse(ctx core.Context, problem map[string]any) *core.Response {
+	contentType := core.NegotiateMediaType(ctx.Header("Accept"), problemTypes...)
+	if contentType == "" {
+		contentType = problemTypes[0]
+	}
+
+	resp := core.NewResponse().
+		WithStatus(problem["status"].(int)).
+		WithHeader("Vary", "Accept")
+
+	if contentType == "text/plain" {
+		text := fmt.Sprintf("%d %s", problem["status"], problem["title"])
+		if detail, ok := problem["detail"].(string); ok {
+			text += ": " + detail
+		}
+		return resp.
+			WithHeader("Content-Type", "text/plain; charset=utf-8").
+			WithBody(text + "\n")
+	}
+	return resp.
+		WithHeader("Content-Type", contentType).
+		WithBody(problem)
+}
+
+// traceIDFromParent extracts the trace ID from a W3C traceparent header
+func traceIDFromParent(traceparent string) string {
+	parts := strings.Split(strings.TrimSpace(traceparent), "-")
+	if len(parts) < 4 || len(parts[1]) != 32 {
+		return ""
+	}
+	return parts[1]
+}
+
+func stringOr(value any, fallback string) string {
+	if s, ok := value.(string); ok && s != "" {
+		return s
+	}
+	return fallback
+}
@@ -0,0 +1,109 @@
+package errorhandler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/http"
+	"sync"
+
+	"github.com/hemant-mann/lumora-go/core"
+)
+
+// StatusClientClosedRequest is the non-standard status used when the client went away
+const StatusClientClosedRequest = 499
+
+// Registry maps errors that are not core.Error values to HTTP errors
+// Mappings are matched with errors.Is or errors.As; the newest matching mapping wins,
+// so applications can override the defaults
+type Registry struct {
+	mu       sync.RWMutex
+	mappings []mapping
+}
+
+// mapping converts err when it matches, or returns nil
+type mapping func(err error) *core.Error
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// DefaultRegistry creates a registry with mappings for common standard library errors
+func DefaultRegistry() *Registry {
+	r := NewRegistry()
+	r.Map(fs.ErrNotExist, http.StatusNotFound, "Not Found")
+	r.Map(fs.ErrPermission, http.StatusForbidden, "Forbidden")
+	r.Map(context.Canceled, StatusClientClosedRequest, "Client Closed Request")
+	r.Map(context.DeadlineExceeded, http.StatusGatewayTimeout, "Gateway Timeout")
+	// Any engine's decode errors are invalid JSON; encoding/json's own types then refine the message
+	MapType[*core.JSONDecodeError](r, http.StatusBadRequest, "Invalid JSON")
+	MapType[*json.SyntaxError](r, http.StatusBadRequest, "Invalid JSON")
+	MapType[*json.UnmarshalTypeError](r, http.StatusBadRequest, "Invalid JSON value")
+	MapType[*http.MaxBytesError](r, http.StatusRequestEntityTooLarge, "Request body too large")
+	return r
+}
+
+// Map maps errors matching target with errors.Is to a status code and public message
+func (r *Registry) Map(target error, code int, message string) *Registry {
+	return r.MapFunc(target, func(err error) *core.Error {
+		return core.WrapError(code, message, err)
+	})
+}
+
+// MapFunc maps errors matching target with errors.Is using convert
+func (r *Registry) MapFunc(target error, convert func(err error) *core.Error) *Registry {
+	return r.add(func(err error) *core.Error {
+		if errors.Is(err, target) {
+			return convert(err)
+		}
+		return nil
+	})
+}
+
+// MapType maps errors whose chain contains an E, found with errors.As, to a status code and public message
+func MapType[E error](r *Registry, code int, message string) *Registry {
+	return r.add(func(err error) *core.Error {
+		var target E
+		if errors.As(err, &target) {
+			return core.WrapError(code, message, err)
+		}
+		return nil
+	})
+}
+
+// MapTypeFunc maps errors whose chain contains an E, found with errors.As, using convert
+func MapTypeFunc[E error](r *Registry, convert func(err E) *core.Error) *Registry {
+	return r.add(func(err error) *core.Error {
+		var target E
+		if errors.As(err, &target) {
+			return convert(target)
+		}
+		return nil
+	})
+}
+
+func (r *Registry) add(m mapping) *Registry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.mappings = append(r.mappings, m)
+	return r
+}
+
+// Resolve returns the HTTP error err maps to, or err unchanged
+// core.Error and core.ValidationError values are already HTTP errors and are never remapped
+func (r *Registry) Resolve(err error) error {
+	if err == nil || core.IsHTTPError(err) || core.GetValidationError(err) != nil {
+		return err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for i := len(r.mappings) - 1; i >= 0; i-- {
+		if httpErr := r.mappings[i](err); httpErr != nil {
+			return httpErr
+		}
+	}
+	return err
+}
@@ -0,0 +1,56 @@
+// Package hook holds what the request hooks (useparams, usequery, useheaders, usecookies,
+// usejsonbody and userequest) share, so they parse and validate requests the same way
+package hook
+
+import (
+	"reflect"
+
+	z "github.com/Oudwins/zog"
+	zi "github.com/Oudwins/zog/internals"
+	"github.com/hemant-mann/lumora-go/core"
+)
+
+// SchemaWithParse is an interface for schemas that have a Parse method
+// This allows us to work with different schema types
+type SchemaWithParse interface {
+	Parse(data any, destPtr any, options ...z.ExecOption) z.ZogIssueList
+}
+
+// NewDest allocates a new instance of the same type as dest (dest must be a pointer to struct).
+// Used per-request to avoid race when parsing into a shared pointer.
+func NewDest(dest any) any {
+	t := reflect.TypeOf(dest)
+	if t == nil || t.Kind() != reflect.Ptr {
+		return dest
+	}
+	return reflect.New(t.Elem()).Interface()
+}
+
+// Parse creates the middleware behind each hook: the request part read by data is parsed with schema
+// into a new value from alloc per request and stored under key
+// describe records the schema on the route, so the part shows up in the OpenAPI document
+// An error returned by data is returned as is, before the schema runs
+func Parse(schema SchemaWithParse, alloc func() any, key string, describe func(route *core.Route, schema *core.RouteSchema), data func(ctx core.Context) (zi.DpFactory, error)) core.Middleware {
+	return func(next core.Handler) core.Handler {
+		core.DescribeRoute(func(route *core.Route) {
+			describe(route, core.NewRouteSchema(schema, alloc()))
+		})
+
+		return func(ctx core.Context) (*core.Response, error) {
+			provider, err := data(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			// Allocate a new destination per request to avoid race when handling concurrent requests
+			dest := alloc()
+			if err := core.IssuesError(schema.Parse(provider, dest)); err != nil {
+				return nil, err
+			}
+
+			ctx.Set(key, dest)
+
+			return next(ctx)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package signedurl
+
+// Example usage of the signedurl middleware:
+//
+// import (
+//     "net/url"
+//     "time"
+//
+//     "github.com/hemant-mann/lumora-go/core"
+//     "github.com/hemant-mann/lumora-go/middleware/signedurl"
+// )
+//
+// // Sign with the first key; keep the previous key until the links it signed have expired
+// app.SetURLSigner(core.NewURLSigner(currentKey, previousKey))
+//
+// app.Get("/downloads/:file", serveDownload, signedurl.Verify()).WithName("download")
+//
+// // Hand out a link valid for one hour, e.g. "/downloads/report.pdf?expires=...&signature=..."
+// link, err := app.SignedURL("download", map[string]string{"file": "report.pdf"}, time.Hour)
+//
+// // Bind the link to a query parameter and to the client requesting it
+// app.Post("/invites", func(ctx core.Context) (*core.Response, error) {
+//     path, err := ctx.URL("accept-invite", nil, url.Values{"team": {"42"}})
+//     if err != nil {
+//         return nil, err
+//     }
+//     link, err := core.GetURLSigner(ctx).Sign(path, 24*time.Hour, &core.SignOptions{
+//         Query:    []string{"team"},
+//         ClientIP: core.ClientIP(ctx.Request()),
+//     })
+//     ...
+// })
+//
+// // Tampered links are rejected with a 403 and the error code "invalid_signature",
+// // expired ones with "signature_expired"
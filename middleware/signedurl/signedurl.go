@@ -0,0 +1,70 @@
+package signedurl
+
+import (
+	"errors"
+
+	"github.com/hemant-mann/lumora-go/core"
+)
+
+// Options represents signed URL verification options
+type Options struct {
+	// Signer verifies the URLs; nil uses the app's, set with App.SetURLSigner
+	Signer *core.URLSigner
+	// ClientIP returns the client address compared for URLs signed for one
+	// Behind a proxy, return the forwarded address the proxy sets
+	ClientIP func(ctx core.Context) string
+}
+
+// DefaultOptions returns default signed URL options: the app's signer and the connection's address
+func DefaultOptions() *Options {
+	return &Options{
+		ClientIP: func(ctx core.Context) string {
+			return core.ClientIP(ctx.Request())
+		},
+	}
+}
+
+// withDefaults returns a copy of options with zero-valued fields set from DefaultOptions
+func withDefaults(options *Options) *Options {
+	defaults := DefaultOptions()
+	if options == nil {
+		return defaults
+	}
+	copied := *options
+	if copied.ClientIP == nil {
+		copied.ClientIP = defaults.ClientIP
+	}
+	return &copied
+}
+
+// New creates a middleware that rejects requests whose URL is not validly signed, or has expired, with a 403
+// Sign URLs with App.SignedURL, or URLSigner.Sign to bind query parameters or the client IP
+// Fields left at their zero value take the value from DefaultOptions
+func New(options *Options) core.Middleware {
+	options = withDefaults(options)
+
+	return func(next core.Handler) core.Handler {
+		return func(ctx core.Context) (*core.Response, error) {
+			signer := options.Signer
+			if signer == nil {
+				signer = core.GetURLSigner(ctx)
+			}
+			if signer == nil {
+				return nil, core.InternalServerError("No URL signer configured")
+			}
+
+			if err := signer.Verify(ctx.Request(), options.ClientIP(ctx)); err != nil {
+				if errors.Is(err, core.ErrSignatureExpired) {
+					return nil, core.Forbidden("This link has expired").WithErrorCode("signature_expired")
+				}
+				return nil, core.Forbidden("Invalid signature").WithErrorCode("invalid_signature")
+			}
+			return next(ctx)
+		}
+	}
+}
+
+// Verify creates a middleware verifying URLs with the app's signer
+func Verify() core.Middleware {
+	return New(DefaultOptions())
+}
@@ -0,0 +1,40 @@
+package usecookies
+
+// Example usage of UseCookies middleware:
+//
+// import (
+//     z "github.com/Oudwins/zog"
+//     "github.com/hemant-mann/lumora-go/core"
+//     "github.com/hemant-mann/lumora-go/middleware/usecookies"
+// )
+//
+// // Define your schema using zog
+// // Cookie values are strings; zog coerces them to the field types
+// var sessionCookiesSchema = z.Struct(z.Shape{
+//     "session": z.String().Min(16),
+//     "theme":   z.String().OneOf([]string{"light", "dark"}).Default("light"),
+//     "consent": z.Bool().Default(false),
+// })
+//
+// // Define your struct
+// // The cookie tag names the cookie; without it the schema key is used
+// type SessionCookies struct {
+//     Session string `cookie:"session_id"`
+//     Theme   string `cookie:"theme"`
+//     Consent bool   `cookie:"cookie_consent"`
+// }
+//
+// // Use in route
+// app.Get("/dashboard",
+//     func(ctx core.Context) (*core.Response, error) {
+//         // Get parsed and validated cookies
+//         cookies := usecookies.From[SessionCookies](ctx)
+//
+//         dashboard := loadDashboard(cookies.Session, cookies.Theme)
+//         resp := core.NewResponse().
+//             WithStatus(200).
+//             WithBody(dashboard)
+//         return resp, nil
+//     },
+//     usecookies.Use[SessionCookies](sessionCookiesSchema),
+// )
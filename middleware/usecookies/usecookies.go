@@ -0,0 +1,76 @@
+package usecookies
+
+import (
+	zi "github.com/Oudwins/zog/internals"
+	"github.com/hemant-mann/lumora-go/core"
+	"github.com/hemant-mann/lumora-go/middleware/internal/hook"
+)
+
+// SchemaWithParse is the schema interface every request hook accepts
+type SchemaWithParse = hook.SchemaWithParse
+
+// cookieTag lets struct fields name their cookie, e.g. `cookie:"session_id"`
+var cookieTag = "cookie"
+
+// cookiesKey is the context key UseCookies and Use store the parsed cookies under
+const cookiesKey = "_cookies"
+
+// UseCookies creates a middleware that validates request cookies and coerces them to typed fields
+// Companion to useHeaders for cookie-based sessions and preferences
+// schema: zog schema for validation (e.g., z.Struct(z.Shape{...})); zog coerces the string values
+// dest: pointer to struct that will hold the parsed cookies (type template; a new instance is used per request)
+// When a cookie name is sent more than once, the first value is used
+func UseCookies(schema SchemaWithParse, dest any) core.Middleware {
+	return UseCookiesWithKey(schema, dest, cookiesKey)
+}
+
+// UseCookiesWithKey creates a middleware that parses cookies and stores them with a custom key
+func UseCookiesWithKey(schema SchemaWithParse, dest any, key string) core.Middleware {
+	return parseCookies(schema, func() any { return hook.NewDest(dest) }, key)
+}
+
+// Use creates a type-safe UseCookies: each request's cookies are coerced into a new T
+// and read back with From[T], without type assertions
+func Use[T any](schema SchemaWithParse) core.Middleware {
+	return UseWithKey(schema, core.NewKey[*T](cookiesKey))
+}
+
+// UseWithKey creates a middleware like Use that stores the parsed cookies under a typed key
+// Read them back with core.GetValue(ctx, key)
+func UseWithKey[T any](schema SchemaWithParse, key core.Key[*T]) core.Middleware {
+	return parseCookies(schema, func() any { return new(T) }, key.Name())
+}
+
+// From returns the cookies parsed by Use[T], or nil when the request was not handled by Use[T]
+func From[T any](ctx core.Context) *T {
+	cookies, _ := core.GetValue(ctx, core.NewKey[*T](cookiesKey))
+	return cookies
+}
+
+// parseCookies parses the cookies into the value returned by alloc and stores it under key
+func parseCookies(schema SchemaWithParse, alloc func() any, key string) core.Middleware {
+	describe := func(route *core.Route, schema *core.RouteSchema) { route.Cookies = schema }
+	return hook.Parse(schema, alloc, key, describe, func(ctx core.Context) (zi.DpFactory, error) { return Data(ctx), nil })
+}
+
+// Data builds a zog data provider from the request cookies, keyed by name; the first of repeated cookies wins
+func Data(ctx core.Context) zi.DpFactory {
+	return func() (zi.DataProvider, *zi.ZogIssue) {
+		cookies := make(map[string]string)
+		for _, cookie := range ctx.Request().Cookies() {
+			if _, exists := cookies[cookie.Name]; !exists {
+				cookies[cookie.Name] = cookie.Value
+			}
+		}
+		return zi.NewMapDataProvider(cookies, &cookieTag), nil
+	}
+}
+
+// GetCookies retrieves the parsed cookies from context
+// Prefer From[T], which returns the cookies already typed
+func GetCookies(ctx core.Context) any {
+	if cookies, ok := ctx.Get(cookiesKey); ok {
+		return cookies
+	}
+	return nil
+}
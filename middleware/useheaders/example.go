@@ -23,11 +23,23 @@ package useheaders
 //     ContentType   string `json:"content-type,omitempty"`
 // }
 //
+// // The header tag maps header names to a field; with several names the first one sent wins
+// // Headers sent more than once arrive as arrays, so validate them with z.Slice
+// var clientHeadersSchema = z.Struct(z.Shape{
+//     "token":     z.String().Required(),
+//     "forwarded": z.Slice(z.String()).Optional(),
+// })
+//
+// type ClientHeaders struct {
+//     Token     string   `header:"x-auth-token,authorization"`
+//     Forwarded []string `header:"x-forwarded-for"`
+// }
+//
 // // Use in route
 // app.Get("/protected",
 //     func(ctx core.Context) (*core.Response, error) {
 //         // Get parsed and validated headers
-//         headers := useheaders.GetHeaders(ctx).(*AuthHeaders)
+//         headers := useheaders.From[AuthHeaders](ctx)
 //
 //         // Use validated headers
 //         resp := core.NewResponse().
@@ -38,5 +50,5 @@ package useheaders
 //             })
 //         return resp, nil
 //     },
-//     useheaders.UseHeaders(authHeadersSchema, &AuthHeaders{}),
+//     useheaders.Use[AuthHeaders](authHeadersSchema),
 // )
@@ -1,131 +1,128 @@
 package useheaders
 
 import (
-	"bytes"
-	"encoding/json"
+	"reflect"
 	"strings"
 
-	z "github.com/Oudwins/zog"
-	"github.com/Oudwins/zog/parsers/zjson"
+	zi "github.com/Oudwins/zog/internals"
 	"github.com/hemant-mann/lumora-go/core"
+	"github.com/hemant-mann/lumora-go/middleware/internal/hook"
 )
 
-// SchemaWithParse is an interface for schemas that have a Parse method
-// This allows us to work with different schema types
-type SchemaWithParse interface {
-	Parse(data any, destPtr any, options ...z.ExecOption) z.ZogIssueList
-}
+// SchemaWithParse is the schema interface every request hook accepts
+type SchemaWithParse = hook.SchemaWithParse
+
+// headersKey is the context key UseHeaders and Use store the parsed headers under
+const headersKey = "_headers"
 
 // UseHeaders creates a middleware that parses and validates request headers
 // Similar to Lumora JS useHeaders hook
 // schema: zog schema for validation (e.g., z.Struct(z.Shape{...}))
-// dest: pointer to struct that will hold the parsed headers
+// dest: pointer to struct that will hold the parsed headers (type template; a new instance is used per request)
+// Headers sent more than once are passed to zog as arrays, so they can fill slice fields
+// A `header:"x-auth-token,authorization"` tag reads the field from the first header present
 func UseHeaders(schema SchemaWithParse, dest any) core.Middleware {
-	return func(next core.Handler) core.Handler {
-		return func(ctx core.Context) (*core.Response, error) {
-			// Extract headers from request
-			req := ctx.Request()
-			headers := make(map[string]string)
-
-			// Convert headers to map for zog parsing
-			// Normalize header names to lowercase for consistent matching
-			for name, values := range req.Header {
-				// HTTP headers can have multiple values, we'll take the first one
-				if len(values) > 0 {
-					// Normalize to lowercase for zog schema matching
-					normalizedName := strings.ToLower(name)
-					headers[normalizedName] = values[0]
-				}
-			}
+	return UseHeadersWithKey(schema, dest, headersKey)
+}
 
-			jsonBody, err := json.Marshal(headers)
-			if err != nil {
-				return nil, core.NewError(400, "Failed to marshal headers to JSON")
-			}
+// UseHeadersWithKey creates a middleware that parses headers and stores them with a custom key
+func UseHeadersWithKey(schema SchemaWithParse, dest any, key string) core.Middleware {
+	return parseHeaders(schema, func() any { return hook.NewDest(dest) }, key)
+}
 
-			// Validate headers using zog schema
-			issues := schema.Parse(zjson.Decode(bytes.NewReader(jsonBody)), dest)
-			if len(issues) > 0 {
-				// Return validation error response
-				resp := core.NewResponse().
-					WithStatus(400).
-					WithBody(map[string]string{"error": formatValidationErrors(issues)})
-				return resp, nil
-			}
+// Use creates a type-safe UseHeaders: the request headers are parsed into a new T per request
+// and read back with From[T], without type assertions
+func Use[T any](schema SchemaWithParse) core.Middleware {
+	return UseWithKey(schema, core.NewKey[*T](headersKey))
+}
 
-			// Store parsed headers in context for access
-			ctx.Set("_headers", dest)
+// UseWithKey creates a middleware like Use that stores the parsed headers under a typed key
+// Read them back with core.GetValue(ctx, key)
+func UseWithKey[T any](schema SchemaWithParse, key core.Key[*T]) core.Middleware {
+	return parseHeaders(schema, func() any { return new(T) }, key.Name())
+}
 
-			return next(ctx)
-		}
-	}
+// From returns the headers parsed by Use[T], or nil if no headers of type T were parsed
+func From[T any](ctx core.Context) *T {
+	headers, _ := core.GetValue(ctx, core.NewKey[*T](headersKey))
+	return headers
 }
 
-// UseHeadersWithKey creates a middleware that parses headers and stores them with a custom key
-func UseHeadersWithKey(schema SchemaWithParse, dest any, key string) core.Middleware {
-	return func(next core.Handler) core.Handler {
-		return func(ctx core.Context) (*core.Response, error) {
-			// Extract headers from request
-			req := ctx.Request()
-			headers := make(map[string]any)
-
-			// Convert headers to map for zog parsing
-			// Normalize header names to lowercase for consistent matching
-			for name, values := range req.Header {
-				// HTTP headers can have multiple values, we'll take the first one
-				if len(values) > 0 {
-					// Normalize to lowercase for zog schema matching
-					normalizedName := strings.ToLower(name)
-					headers[normalizedName] = values[0]
-				}
-			}
-			jsonBody, err := json.Marshal(headers)
-			if err != nil {
-				return nil, core.NewError(400, "Failed to marshal headers to JSON")
-			}
+// parseHeaders parses the headers into the value returned by alloc and stores it under key
+func parseHeaders(schema SchemaWithParse, alloc func() any, key string) core.Middleware {
+	describe := func(route *core.Route, schema *core.RouteSchema) { route.Headers = schema }
+	return hook.Parse(schema, alloc, key, describe, func(ctx core.Context) (zi.DpFactory, error) { return Data(ctx), nil })
+}
 
-			// Validate headers using zog schema
-			issues := schema.Parse(zjson.Decode(bytes.NewReader(jsonBody)), dest)
-			if len(issues) > 0 {
-				// Return validation error response
-				resp := core.NewResponse().
-					WithStatus(400).
-					WithBody(map[string]string{"error": formatValidationErrors(issues)})
-				return resp, nil
+// Data builds a zog data provider from the request headers
+// Names are normalized to lowercase; a header sent once maps to a string, repeated headers to a []string
+func Data(ctx core.Context) zi.DpFactory {
+	return func() (zi.DataProvider, *zi.ZogIssue) {
+		headers := make(map[string]any, len(ctx.Request().Header))
+		for name, values := range ctx.Request().Header {
+			name = strings.ToLower(name)
+			switch len(values) {
+			case 0:
+				continue
+			case 1:
+				headers[name] = values[0]
+			default:
+				// net/http keys are canonical, so all values of a header are already together
+				headers[name] = append([]string(nil), values...)
 			}
-
-			// Store parsed headers in context with custom key
-			ctx.Set(key, dest)
-
-			return next(ctx)
 		}
+		return &headerProvider{headers: headers}, nil
 	}
 }
 
-// GetHeaders retrieves the parsed headers from context
-func GetHeaders(ctx core.Context) any {
-	if headers, ok := ctx.Get("_headers"); ok {
-		return headers
-	}
-	return nil
+// headerProvider resolves struct fields to header names
+// The `header` tag lists one or more names, the first one present wins; otherwise the json tag,
+// the zog tag and finally the schema key are used. Names are matched case-insensitively
+type headerProvider struct {
+	headers map[string]any
 }
 
-// formatValidationErrors formats zog validation errors into a readable string
-func formatValidationErrors(issues z.ZogIssueList) string {
-	if len(issues) == 0 {
-		return "Validation failed"
+func (p *headerProvider) Get(key string) any {
+	if value, ok := p.headers[strings.ToLower(key)]; ok {
+		return value
 	}
+	return nil
+}
 
-	// Format errors as a simple message
-	// Could be enhanced to return structured error response
-	msg := "Validation errors: "
-	for i := 0; i < len(issues); i++ {
-		if i > 0 {
-			msg += "; "
+func (p *headerProvider) GetByField(field reflect.StructField, fallback string) (any, string) {
+	if tag, ok := field.Tag.Lookup("header"); ok && tag != "" {
+		names := strings.Split(tag, ",")
+		for _, name := range names {
+			name = strings.ToLower(strings.TrimSpace(name))
+			if value, ok := p.headers[name]; ok {
+				return value, name
+			}
 		}
-		if issues[i] != nil {
-			msg += issues[i].Error()
+		return nil, strings.ToLower(strings.TrimSpace(names[0]))
+	}
+	if tag, ok := field.Tag.Lookup("json"); ok {
+		if name, _, _ := strings.Cut(tag, ","); name != "" && name != "-" {
+			return p.Get(name), strings.ToLower(name)
 		}
 	}
-	return msg
+	key := strings.ToLower(zi.GetKeyFromField(field, fallback, nil))
+	return p.Get(key), key
+}
+
+func (p *headerProvider) GetNestedProvider(key string) zi.DataProvider {
+	provider, _ := zi.TryNewAnyDataProvider(p.Get(key))
+	return provider
+}
+
+func (p *headerProvider) GetUnderlying() any {
+	return p.headers
+}
+
+// GetHeaders retrieves the parsed headers from context
+// Prefer From[T], which returns the headers already typed
+func GetHeaders(ctx core.Context) any {
+	if headers, ok := ctx.Get(headersKey); ok {
+		return headers
+	}
+	return nil
 }
@@ -26,9 +26,9 @@ package usejsonbody
 // app.Post("/users",
 //     func(ctx core.Context) error {
 //         // Get parsed and validated body
-//         user := usejsonbody.GetJsonBody(ctx).(*User)
-//         // Or access directly from context
-//         // user := ctx.Get("_jsonBody").(*User)
+//         user := usejsonbody.From[User](ctx)
+//         // Or with the untyped API
+//         // user := usejsonbody.GetJsonBody(ctx).(*User)
 //
 //         // Use validated user data
 //         resp := core.NewResponse().
@@ -39,5 +39,5 @@ package usejsonbody
 //             })
 //         return resp.Send(ctx)
 //     },
-//     usejsonbody.UseJsonBody(userSchema, &User{}),
+//     usejsonbody.Use[User](userSchema),
 // )
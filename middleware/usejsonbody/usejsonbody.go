@@ -1,129 +1,112 @@
 package usejsonbody
 
 import (
-	"bytes"
-	"reflect"
+	"errors"
 
-	z "github.com/Oudwins/zog"
-	"github.com/Oudwins/zog/parsers/zjson"
+	zi "github.com/Oudwins/zog/internals"
+	"github.com/Oudwins/zog/zconst"
 	"github.com/hemant-mann/lumora-go/core"
+	"github.com/hemant-mann/lumora-go/middleware/internal/hook"
 )
 
-// SchemaWithParse is an interface for schemas that have a Parse method
-// This allows us to work with different schema types
-type SchemaWithParse interface {
-	Parse(data any, destPtr any, options ...z.ExecOption) z.ZogIssueList
-}
+// SchemaWithParse is the schema interface every request hook accepts
+type SchemaWithParse = hook.SchemaWithParse
 
-// newDest allocates a new instance of the same type as dest (dest must be a pointer to struct).
-// Used per-request to avoid race when parsing JSON into a shared pointer.
-func newDest(dest any) any {
-	t := reflect.TypeOf(dest)
-	if t == nil || t.Kind() != reflect.Ptr {
-		return dest
-	}
-	return reflect.New(t.Elem()).Interface()
-}
+// bodyKey is the context key UseJsonBody and Use store the parsed body under
+const bodyKey = "_jsonBody"
 
 // UseJsonBody creates a middleware that parses and validates JSON request body
 // Similar to Lumora JS useJsonBody hook
 // schema: zog schema for validation (e.g., z.Struct(z.Shape{...}))
 // dest: pointer to struct that will hold the parsed data (type template; a new instance is used per request)
 func UseJsonBody(schema SchemaWithParse, dest any) core.Middleware {
-	return func(next core.Handler) core.Handler {
-		return func(ctx core.Context) (*core.Response, error) {
-			// Allocate a new destination per request to avoid race when handling concurrent requests
-			perRequestDest := newDest(dest)
-			// Use RequestBody() method which works across all adapters
-			body, err := ctx.RequestBody()
-			if err != nil {
-				return nil, core.NewError(400, "Failed to read request body")
-			}
-
-			if len(body) == 0 {
-				return nil, core.NewError(400, "Request body is empty")
-			}
-
-			// Decode and validate JSON using zjson
-			issues := schema.Parse(zjson.Decode(bytes.NewReader(body)), perRequestDest)
-			if len(issues) > 0 {
-				// Return validation error response
-				resp := core.NewResponse().
-					WithStatus(400).
-					WithBody(map[string]string{"error": formatValidationErrors(issues)})
-				return resp, nil
-			}
-
-			// Store parsed body in context for access
-			ctx.Set("_jsonBody", perRequestDest)
-
-			return next(ctx)
-		}
-	}
+	return UseJsonBodyWithKey(schema, dest, bodyKey)
 }
 
 // UseJsonBodyWithKey creates a middleware that parses JSON and stores it with a custom key
 func UseJsonBodyWithKey(schema SchemaWithParse, dest any, key string) core.Middleware {
-	return func(next core.Handler) core.Handler {
-		return func(ctx core.Context) (*core.Response, error) {
-			// Allocate a new destination per request to avoid race when handling concurrent requests
-			perRequestDest := newDest(dest)
-			// Use RequestBody() method which works across all adapters
-			body, err := ctx.RequestBody()
-			if err != nil {
-				return nil, core.NewError(400, "Failed to read request body")
-			}
-
-			if len(body) == 0 {
-				return nil, core.NewError(400, "Request body is empty")
-			}
-
-			// Decode and validate JSON using zjson
-			issues := schema.Parse(zjson.Decode(bytes.NewReader(body)), perRequestDest)
-			if len(issues) > 0 {
-				// Return validation error response
-				resp := core.NewResponse().
-					WithStatus(400).
-					WithBody(map[string]string{"error": formatValidationErrors(issues)})
-				return resp, nil
-			}
-
-			// Store parsed body in context with custom key
-			ctx.Set(key, perRequestDest)
-
-			return next(ctx)
-		}
-	}
+	return parseBody(schema, func() any { return hook.NewDest(dest) }, key)
 }
 
-func SetJsonBody(ctx core.Context, dest any) {
-	ctx.Set("_jsonBody", dest)
+// Use creates a type-safe UseJsonBody: the JSON body is decoded into a new T per request
+// and read back with From[T], without type assertions
+func Use[T any](schema SchemaWithParse) core.Middleware {
+	return UseWithKey(schema, core.NewKey[*T](bodyKey))
 }
 
-// GetJsonBody retrieves the parsed JSON body from context
-func GetJsonBody(ctx core.Context) any {
-	if body, ok := ctx.Get("_jsonBody"); ok {
-		return body
+// UseWithKey creates a middleware like Use that stores the decoded body under a typed key
+// Read it back with core.GetValue(ctx, key)
+func UseWithKey[T any](schema SchemaWithParse, key core.Key[*T]) core.Middleware {
+	return parseBody(schema, func() any { return new(T) }, key.Name())
+}
+
+// From returns the body decoded by Use[T], or nil if no body of type T was decoded
+func From[T any](ctx core.Context) *T {
+	body, _ := core.GetValue(ctx, core.NewKey[*T](bodyKey))
+	return body
+}
+
+// parseBody parses the body into the value returned by alloc and stores it under key
+func parseBody(schema SchemaWithParse, alloc func() any, key string) core.Middleware {
+	describe := func(route *core.Route, schema *core.RouteSchema) { route.Body = schema }
+	return hook.Parse(schema, alloc, key, describe, requireBody)
+}
+
+// requireBody reads the request body and decodes it with the app's JSON engine
+// A body that can't be read or is empty is rejected with a 400 before validation
+func requireBody(ctx core.Context) (zi.DpFactory, error) {
+	// Use RequestBody() method which works across all adapters
+	body, err := ctx.RequestBody()
+	if err != nil {
+		return nil, core.NewError(400, "Failed to read request body")
 	}
-	return nil
+	if len(body) == 0 {
+		return nil, core.NewError(400, "Request body is empty")
+	}
+	return decodeJSON(core.GetJSONEngine(ctx), body), nil
 }
 
-// formatValidationErrors formats zog validation errors into a readable string
-func formatValidationErrors(issues z.ZogIssueList) string {
-	if len(issues) == 0 {
-		return "Validation failed"
+// jsonTag makes zog match struct fields by their json tag, like zjson.Decode
+var jsonTag = "json"
+
+// Data builds a zog data provider from the JSON request body, decoded with the app's JSON engine
+// A body that can't be read, is empty or isn't a JSON object is reported as an issue
+func Data(ctx core.Context) zi.DpFactory {
+	return func() (zi.DataProvider, *zi.ZogIssue) {
+		body, err := ctx.RequestBody()
+		if err != nil {
+			return nil, &zi.ZogIssue{Code: zconst.IssueCodeInvalidJSON, Message: "failed to read request body", Err: err}
+		}
+		if len(body) == 0 {
+			return nil, &zi.ZogIssue{Code: zconst.IssueCodeRequired, Message: "request body is empty"}
+		}
+		return decodeJSON(core.GetJSONEngine(ctx), body)()
 	}
+}
 
-	// Format errors as a simple message
-	// Could be enhanced to return structured error response
-	msg := "Validation errors: "
-	for i := 0; i < len(issues); i++ {
-		if i > 0 {
-			msg += "; "
+// decodeJSON is zjson.Decode using the app's JSON engine instead of encoding/json
+func decodeJSON(engine core.JSONEngine, body []byte) zi.DpFactory {
+	return func() (zi.DataProvider, *zi.ZogIssue) {
+		var m map[string]any
+		if err := engine.Unmarshal(body, &m); err != nil {
+			return nil, &zi.ZogIssue{Code: zconst.IssueCodeInvalidJSON, Message: "request body is not a valid JSON object", Err: err}
 		}
-		if issues[i] != nil {
-			msg += issues[i].Error()
+		if m == nil {
+			return nil, &zi.ZogIssue{Code: zconst.IssueCodeInvalidJSON, Message: "request body is not a valid JSON object", Err: errors.New("nil json body")}
 		}
+		return zi.NewMapDataProvider(m, &jsonTag), nil
+	}
+}
+
+func SetJsonBody(ctx core.Context, dest any) {
+	ctx.Set(bodyKey, dest)
+}
+
+// GetJsonBody retrieves the parsed JSON body from context
+// Prefer From[T], which returns the body already typed
+func GetJsonBody(ctx core.Context) any {
+	if body, ok := ctx.Get(bodyKey); ok {
+		return body
 	}
-	return msg
+	return nil
 }
@@ -0,0 +1,40 @@
+package useparams
+
+// Example usage of UseParams middleware:
+//
+// import (
+//     z "github.com/Oudwins/zog"
+//     "github.com/hemant-mann/lumora-go/core"
+//     "github.com/hemant-mann/lumora-go/middleware/useparams"
+// )
+//
+// // Define your schema using zog
+// // Path params are strings; zog coerces them to the field types
+// var orderParamsSchema = z.Struct(z.Shape{
+//     "userID":  z.String().UUID(),
+//     "orderID": z.Int().GT(0),
+//     "status":  z.String().OneOf([]string{"open", "shipped", "cancelled"}),
+// })
+//
+// // Define your struct
+// // The param tag names the path parameter; without it the schema key is used
+// type OrderParams struct {
+//     UserID  string `param:"userId"`
+//     OrderID int    `param:"orderId"`
+//     Status  string `param:"status"`
+// }
+//
+// // Use in route
+// app.Get("/users/:userId/orders/:orderId/:status",
+//     func(ctx core.Context) (*core.Response, error) {
+//         // Get parsed and validated params
+//         params := useparams.From[OrderParams](ctx)
+//
+//         order := findOrder(params.UserID, params.OrderID, params.Status)
+//         resp := core.NewResponse().
+//             WithStatus(200).
+//             WithBody(order)
+//         return resp, nil
+//     },
+//     useparams.Use[OrderParams](orderParamsSchema),
+// )
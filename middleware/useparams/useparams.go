@@ -0,0 +1,69 @@
+package useparams
+
+import (
+	zi "github.com/Oudwins/zog/internals"
+	"github.com/hemant-mann/lumora-go/core"
+	"github.com/hemant-mann/lumora-go/middleware/internal/hook"
+)
+
+// SchemaWithParse is the schema interface every request hook accepts
+type SchemaWithParse = hook.SchemaWithParse
+
+// paramTag lets struct fields name their path parameter, e.g. `param:"user_id"`
+var paramTag = "param"
+
+// paramsKey is the context key UseParams and Use store the parsed params under
+const paramsKey = "_params"
+
+// UseParams creates a middleware that validates path parameters and coerces them to typed fields
+// Similar to Lumora JS useParams hook
+// schema: zog schema for validation (e.g., z.Struct(z.Shape{...})); zog coerces the string values
+// dest: pointer to struct that will hold the parsed params (type template; a new instance is used per request)
+func UseParams(schema SchemaWithParse, dest any) core.Middleware {
+	return UseParamsWithKey(schema, dest, paramsKey)
+}
+
+// UseParamsWithKey creates a middleware that parses path parameters and stores them with a custom key
+func UseParamsWithKey(schema SchemaWithParse, dest any, key string) core.Middleware {
+	return parseParams(schema, func() any { return hook.NewDest(dest) }, key)
+}
+
+// Use creates a type-safe UseParams: the path parameters are coerced into a new T per request
+// and read back with From[T], without type assertions
+func Use[T any](schema SchemaWithParse) core.Middleware {
+	return UseWithKey(schema, core.NewKey[*T](paramsKey))
+}
+
+// UseWithKey creates a middleware like Use that stores the path parameters under a typed key
+// Read them back with core.GetValue(ctx, key)
+func UseWithKey[T any](schema SchemaWithParse, key core.Key[*T]) core.Middleware {
+	return parseParams(schema, func() any { return new(T) }, key.Name())
+}
+
+// From returns the path parameters parsed by Use[T], or nil if the route has no Use[T]
+func From[T any](ctx core.Context) *T {
+	params, _ := core.GetValue(ctx, core.NewKey[*T](paramsKey))
+	return params
+}
+
+// parseParams parses the params into the value returned by alloc and stores it under key
+func parseParams(schema SchemaWithParse, alloc func() any, key string) core.Middleware {
+	describe := func(route *core.Route, schema *core.RouteSchema) { route.Params = schema }
+	return hook.Parse(schema, alloc, key, describe, func(ctx core.Context) (zi.DpFactory, error) { return Data(ctx), nil })
+}
+
+// Data builds a zog data provider from the route's path parameters, read with ctx.Params
+func Data(ctx core.Context) zi.DpFactory {
+	return func() (zi.DataProvider, *zi.ZogIssue) {
+		return zi.NewMapDataProvider(ctx.Params(), &paramTag), nil
+	}
+}
+
+// GetParams retrieves the parsed params from context
+// Prefer From[T], which returns the params already typed
+func GetParams(ctx core.Context) any {
+	if params, ok := ctx.Get(paramsKey); ok {
+		return params
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package usequery
+
+// Example usage of UseQuery middleware:
+//
+// import (
+//     z "github.com/Oudwins/zog"
+//     "github.com/hemant-mann/lumora-go/core"
+//     "github.com/hemant-mann/lumora-go/middleware/usequery"
+// )
+//
+// // Define your schema using zog
+// // Query values are strings; zog coerces them to the field types
+// var listUsersQuerySchema = z.Struct(z.Shape{
+//     "page":    z.Int().GTE(1).Default(1),
+//     "perPage": z.Int().GTE(1).LTE(100).Default(20),
+//     "sort":    z.String().OneOf([]string{"asc", "desc"}).Default("asc"),
+//     "tags":    z.Slice(z.String()).Optional(),
+// })
+//
+// // Define your struct
+// // The query tag names the query parameter; without it the schema key is used
+// type ListUsersQuery struct {
+//     Page    int      `query:"page"`
+//     PerPage int      `query:"per_page"`
+//     Sort    string   `query:"sort"`
+//     Tags    []string `query:"tag"` // ?tag=admin&tag=staff
+// }
+//
+// // Use in route
+// app.Get("/users",
+//     func(ctx core.Context) (*core.Response, error) {
+//         // Get parsed and validated query
+//         query := usequery.From[ListUsersQuery](ctx)
+//
+//         users := listUsers(query.Page, query.PerPage, query.Sort, query.Tags)
+//         resp := core.NewResponse().
+//             WithStatus(200).
+//             WithBody(users)
+//         return resp, nil
+//     },
+//     usequery.Use[ListUsersQuery](listUsersQuerySchema),
+// )
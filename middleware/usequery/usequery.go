@@ -0,0 +1,80 @@
+package usequery
+
+import (
+	zi "github.com/Oudwins/zog/internals"
+	"github.com/hemant-mann/lumora-go/core"
+	"github.com/hemant-mann/lumora-go/middleware/internal/hook"
+)
+
+// SchemaWithParse is the schema interface every request hook accepts
+type SchemaWithParse = hook.SchemaWithParse
+
+// queryTag lets struct fields name their query parameter, e.g. `query:"page_size"`
+var queryTag = "query"
+
+// queryKey is the context key UseQuery and Use store the parsed query under
+const queryKey = "_query"
+
+// UseQuery creates a middleware that parses and validates query parameters
+// Similar to Lumora JS useQuery hook
+// schema: zog schema for validation (e.g., z.Struct(z.Shape{...})); zog coerces the string values
+// dest: pointer to struct that will hold the parsed query (type template; a new instance is used per request)
+// Repeated keys (?tag=a&tag=b) are passed to zog as arrays, so they can fill slice fields
+func UseQuery(schema SchemaWithParse, dest any) core.Middleware {
+	return UseQueryWithKey(schema, dest, queryKey)
+}
+
+// UseQueryWithKey creates a middleware that parses query parameters and stores them with a custom key
+func UseQueryWithKey(schema SchemaWithParse, dest any, key string) core.Middleware {
+	return parseQuery(schema, func() any { return hook.NewDest(dest) }, key)
+}
+
+// Use creates a type-safe UseQuery: the query string is parsed into a new T per request
+// and read back with From[T], without type assertions
+func Use[T any](schema SchemaWithParse) core.Middleware {
+	return UseWithKey(schema, core.NewKey[*T](queryKey))
+}
+
+// UseWithKey creates a middleware like Use that stores the parsed query under a typed key
+// Read it back with core.GetValue(ctx, key)
+func UseWithKey[T any](schema SchemaWithParse, key core.Key[*T]) core.Middleware {
+	return parseQuery(schema, func() any { return new(T) }, key.Name())
+}
+
+// From returns the query parsed by Use[T], or nil if Use[T] did not run for this request
+func From[T any](ctx core.Context) *T {
+	query, _ := core.GetValue(ctx, core.NewKey[*T](queryKey))
+	return query
+}
+
+// parseQuery parses the query into the value returned by alloc and stores it under key
+func parseQuery(schema SchemaWithParse, alloc func() any, key string) core.Middleware {
+	describe := func(route *core.Route, schema *core.RouteSchema) { route.Query = schema }
+	return hook.Parse(schema, alloc, key, describe, func(ctx core.Context) (zi.DpFactory, error) { return Data(ctx), nil })
+}
+
+// Data builds a zog data provider from all query values
+// Keys with a single value map to a string, repeated keys to a []string
+func Data(ctx core.Context) zi.DpFactory {
+	return func() (zi.DataProvider, *zi.ZogIssue) {
+		query := ctx.Request().URL.Query()
+		data := make(map[string]any, len(query))
+		for name, values := range query {
+			if len(values) == 1 {
+				data[name] = values[0]
+			} else {
+				data[name] = values
+			}
+		}
+		return zi.NewMapDataProvider(data, &queryTag), nil
+	}
+}
+
+// GetQuery retrieves the parsed query from context
+// Prefer From[T], which returns the query already typed
+func GetQuery(ctx core.Context) any {
+	if query, ok := ctx.Get(queryKey); ok {
+		return query
+	}
+	return nil
+}
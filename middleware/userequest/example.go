@@ -0,0 +1,71 @@
+package userequest
+
+// Example usage of UseRequest middleware:
+//
+// import (
+//     z "github.com/Oudwins/zog"
+//     "github.com/hemant-mann/lumora-go/core"
+//     "github.com/hemant-mann/lumora-go/middleware/userequest"
+// )
+//
+// // Define one zog schema per request section
+// var updateOrderSchemas = userequest.Schemas{
+//     Params: z.Struct(z.Shape{
+//         "iD": z.Int().GT(0),
+//     }),
+//     Query: z.Struct(z.Shape{
+//         "notify": z.Bool().Default(false),
+//     }),
+//     Headers: z.Struct(z.Shape{
+//         "authorization": z.String().Required(),
+//     }),
+//     Cookies: z.Struct(z.Shape{
+//         "session": z.String().Min(4),
+//     }),
+//     Body: z.Struct(z.Shape{
+//         "status": z.String().OneOf([]string{"pending", "shipped"}),
+//         "note":   z.String().Optional(),
+//     }),
+// }
+//
+// // Define the request struct; each section is a field with the matching name
+// // Section tags are param, query, header (lowercase), cookie and json (or form)
+// type UpdateOrderRequest struct {
+//     Params struct {
+//         ID int `param:"id"`
+//     }
+//     Query struct {
+//         Notify bool `query:"notify"`
+//     }
+//     Headers struct {
+//         Authorization string `header:"authorization"`
+//     }
+//     Cookies struct {
+//         Session string `cookie:"session"`
+//     }
+//     Body struct {
+//         Status string `json:"status"`
+//         Note   string `json:"note"`
+//     }
+// }
+//
+// // Use in route
+// app.Put("/orders/:id",
+//     func(ctx core.Context) (*core.Response, error) {
+//         // Get parsed and validated request
+//         req := userequest.GetRequest[UpdateOrderRequest](ctx)
+//
+//         order := updateOrder(req.Params.ID, req.Body.Status, req.Body.Note, req.Query.Notify)
+//         resp := core.NewResponse().
+//             WithStatus(200).
+//             WithBody(order)
+//         return resp, nil
+//     },
+//     userequest.UseRequest[UpdateOrderRequest](updateOrderSchemas),
+// )
+//
+// // Every failing section is reported in one core.ValidationError, rendered by errorhandler as:
+// // 400 {"error": "Validation failed", "fields": [
+// //     {"path": "params.id", "code": "gt", "message": "...", "params": {"gt": 0}},
+// //     {"path": "body.status", "code": "one_of", "message": "..."}
+// // ]}
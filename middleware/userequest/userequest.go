@@ -0,0 +1,193 @@
+package userequest
+
+import (
+	"fmt"
+	"mime"
+	"reflect"
+	"strings"
+
+	zi "github.com/Oudwins/zog/internals"
+	"github.com/Oudwins/zog/zconst"
+	"github.com/hemant-mann/lumora-go/core"
+	"github.com/hemant-mann/lumora-go/middleware/internal/hook"
+	"github.com/hemant-mann/lumora-go/middleware/usecookies"
+	"github.com/hemant-mann/lumora-go/middleware/useheaders"
+	"github.com/hemant-mann/lumora-go/middleware/usejsonbody"
+	"github.com/hemant-mann/lumora-go/middleware/useparams"
+	"github.com/hemant-mann/lumora-go/middleware/usequery"
+)
+
+// SchemaWithParse is the schema interface every request hook accepts
+type SchemaWithParse = hook.SchemaWithParse
+
+// Schemas holds one zog schema per request section; nil sections are skipped
+type Schemas struct {
+	Params  SchemaWithParse
+	Query   SchemaWithParse
+	Headers SchemaWithParse
+	Cookies SchemaWithParse
+	Body    SchemaWithParse
+}
+
+// formTag names form body fields; JSON bodies and the other sections use the tags of their hooks
+var formTag = "form"
+
+// requestKey is the context key UseRequest stores the parsed request under
+const requestKey = "_request"
+
+// section describes how to fill one field of the request struct
+type section struct {
+	name   string
+	field  int
+	schema SchemaWithParse
+	data   func(ctx core.Context) zi.DpFactory
+}
+
+// UseRequest creates a middleware that validates every part of the request in one pass
+// Similar to Lumora JS useRequest hook
+// T is a struct with any of the fields Params, Query, Headers, Cookies and Body,
+// each a struct validated by the matching schema in schemas
+// All issues are collected into a single core.ValidationError whose paths start with the
+// section name, e.g. "body.email"; on success the handler reads the typed result with GetRequest[T]
+func UseRequest[T any](schemas Schemas) core.Middleware {
+	return UseRequestWithKey[T](schemas, requestKey)
+}
+
+// Use is UseRequest, named like the other hooks' generic constructors
+func Use[T any](schemas Schemas) core.Middleware {
+	return UseRequest[T](schemas)
+}
+
+// UseWithKey creates a middleware like UseRequest that stores the result under a typed key
+// Read it back with core.GetValue(ctx, key)
+func UseWithKey[T any](schemas Schemas, key core.Key[*T]) core.Middleware {
+	return UseRequestWithKey[T](schemas, key.Name())
+}
+
+// UseRequestWithKey creates a middleware like UseRequest that stores the result with a custom key
+func UseRequestWithKey[T any](schemas Schemas, key string) core.Middleware {
+	// Resolve the sections once; a schema without a matching field is a programming error
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	sections := resolveSections(t, schemas)
+
+	return func(next core.Handler) core.Handler {
+		// Record the section schemas on the route so they show up in the OpenAPI document
+		core.DescribeRoute(func(route *core.Route) {
+			for _, s := range sections {
+				describeSection(route, s.name, &core.RouteSchema{Schema: s.schema, Type: t.Field(s.field).Type})
+			}
+		})
+
+		return func(ctx core.Context) (*core.Response, error) {
+			// Allocate a new result per request to avoid race when handling concurrent requests
+			result := new(T)
+			value := reflect.ValueOf(result).Elem()
+
+			var fields []core.FieldError
+			for _, s := range sections {
+				dest := value.Field(s.field).Addr().Interface()
+				issues := s.schema.Parse(s.data(ctx), dest)
+				for _, field := range core.NewValidationError(issues).Fields {
+					field.Path = strings.TrimSuffix(s.name+"."+field.Path, ".")
+					fields = append(fields, field)
+				}
+			}
+			if len(fields) > 0 {
+				// Return the issues of every section as one error so errorhandler renders them together
+				return nil, &core.ValidationError{Fields: fields}
+			}
+
+			// Store the parsed request in context for access
+			ctx.Set(key, result)
+
+			return next(ctx)
+		}
+	}
+}
+
+// GetRequest retrieves the parsed request from context
+func GetRequest[T any](ctx core.Context) *T {
+	return GetRequestWithKey[T](ctx, requestKey)
+}
+
+// From is GetRequest, named like the other hooks' generic accessors
+func From[T any](ctx core.Context) *T {
+	return GetRequest[T](ctx)
+}
+
+// GetRequestWithKey retrieves a request parsed by UseRequestWithKey
+func GetRequestWithKey[T any](ctx core.Context, key string) *T {
+	request, _ := core.GetValue(ctx, core.NewKey[*T](key))
+	return request
+}
+
+func resolveSections(t reflect.Type, schemas Schemas) []section {
+	if t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("userequest: %s is not a struct", t))
+	}
+	candidates := []struct {
+		name   string
+		field  string
+		schema SchemaWithParse
+		data   func(ctx core.Context) zi.DpFactory
+	}{
+		{"params", "Params", schemas.Params, useparams.Data},
+		{"query", "Query", schemas.Query, usequery.Data},
+		{"headers", "Headers", schemas.Headers, useheaders.Data},
+		{"cookies", "Cookies", schemas.Cookies, usecookies.Data},
+		{"body", "Body", schemas.Body, bodyData},
+	}
+
+	var sections []section
+	for _, c := range candidates {
+		if c.schema == nil {
+			continue
+		}
+		field, ok := t.FieldByName(c.field)
+		if !ok || len(field.Index) != 1 {
+			panic(fmt.Sprintf("userequest: %s has a %s schema but no %s field", t, c.name, c.field))
+		}
+		sections = append(sections, section{name: c.name, field: field.Index[0], schema: c.schema, data: c.data})
+	}
+	return sections
+}
+
+// describeSection sets the route's request part for the named section
+func describeSection(route *core.Route, name string, schema *core.RouteSchema) {
+	switch name {
+	case "params":
+		route.Params = schema
+	case "query":
+		route.Query = schema
+	case "headers":
+		route.Headers = schema
+	case "cookies":
+		route.Cookies = schema
+	case "body":
+		route.Body = schema
+	}
+}
+
+// bodyData decodes URL-encoded and multipart bodies as form values and anything else as JSON,
+// with the provider usejsonbody parses its bodies with
+func bodyData(ctx core.Context) zi.DpFactory {
+	mediaType, _, _ := mime.ParseMediaType(ctx.Header("Content-Type"))
+	if mediaType != "application/x-www-form-urlencoded" && mediaType != "multipart/form-data" {
+		return usejsonbody.Data(ctx)
+	}
+	return func() (zi.DataProvider, *zi.ZogIssue) {
+		values, err := ctx.PostFormValues()
+		if err != nil {
+			return nil, &zi.ZogIssue{Code: zconst.IssueCodeZHTTPInvalidForm, Message: "invalid form body", Err: err}
+		}
+		data := make(map[string]any, len(values))
+		for name, v := range values {
+			if len(v) == 1 {
+				data[name] = v[0]
+			} else {
+				data[name] = v
+			}
+		}
+		return zi.NewMapDataProvider(data, &formTag), nil
+	}
+}
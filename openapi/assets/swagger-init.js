@@ -0,0 +1,5 @@
+// Starts Swagger UI on the document named by the page, without an inline script
+window.addEventListener("load", function () {
+  var root = document.getElementById("swagger-ui");
+  window.ui = SwaggerUIBundle({ url: root.dataset.url, dom_id: "#swagger-ui" });
+});
@@ -0,0 +1,250 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	z "github.com/Oudwins/zog"
+	"github.com/hemant-mann/lumora-go/core"
+)
+
+// Options represents OpenAPI document configuration options
+type Options struct {
+	// Title, Version and Description fill the document's info object
+	Title       string
+	Version     string
+	Description string
+	// Servers lists the base URLs of the API
+	Servers []Server
+	// Path serves the JSON document; empty disables it
+	Path string
+	// DocsPath serves the Swagger UI documentation page, with its assets under DocsPath/assets;
+	// empty disables it
+	DocsPath string
+}
+
+// DefaultOptions returns default OpenAPI options
+func DefaultOptions() *Options {
+	return &Options{
+		Title:    "API",
+		Version:  "1.0.0",
+		Path:     "/openapi.json",
+		DocsPath: "/docs",
+	}
+}
+
+// Mount serves the document generated from app's routes at options.Path and the documentation page at options.DocsPath
+// The document is generated on each request, so routes registered after Mount are included
+func Mount(app core.App, options *Options, middlewares ...core.Middleware) {
+	if options == nil {
+		options = DefaultOptions()
+	}
+
+	if options.Path != "" {
+		app.Get(options.Path, func(ctx core.Context) (*core.Response, error) {
+			resp := core.NewResponse().
+				WithStatus(200).
+				WithHeader("Content-Type", "application/json").
+				WithBody(Generate(app.Routes(), options))
+			return resp, nil
+		}, middlewares...)
+	}
+	if options.DocsPath != "" {
+		mountAssets(app, options, middlewares)
+		app.Get(options.DocsPath, func(ctx core.Context) (*core.Response, error) {
+			page, err := docsPage(options)
+			if err != nil {
+				return nil, err
+			}
+			resp := core.NewResponse().
+				WithStatus(200).
+				WithHeader("Content-Type", "text/html; charset=utf-8").
+				WithBody(page)
+			return resp, nil
+		}, middlewares...)
+	}
+}
+
+// Generate builds an OpenAPI document from routes
+// The routes serving the document itself are left out
+func Generate(routes []*core.Route, options *Options) *Document {
+	if options == nil {
+		options = DefaultOptions()
+	}
+	doc := &Document{
+		OpenAPI: Version,
+		Info: Info{
+			Title:       options.Title,
+			Version:     options.Version,
+			Description: options.Description,
+		},
+		Servers: options.Servers,
+		Paths:   make(map[string]*PathItem),
+	}
+
+	for _, route := range routes {
+		if route.Path == options.Path || route.Path == options.DocsPath || strings.HasPrefix(route.Path, assetsPath(options)+"/") {
+			continue
+		}
+		path, pathParams := convertPath(route.Path)
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = &PathItem{}
+			doc.Paths[path] = item
+		}
+		(*item)[strings.ToLower(route.Method)] = operation(route, pathParams)
+	}
+	return doc
+}
+
+// convertPath turns a route pattern into an OpenAPI path template
+// "/users/:id" becomes "/users/{id}" and "/static/*filepath" becomes "/static/{filepath}"
+func convertPath(pattern string) (string, []string) {
+	segments := strings.Split(pattern, "/")
+	var params []string
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			name := segment[1:]
+			params = append(params, name)
+			segments[i] = "{" + name + "}"
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// operation documents a single route
+func operation(route *core.Route, pathParams []string) *Operation {
+	op := &Operation{
+		OperationID: route.OperationID,
+		Summary:     route.Summary,
+		Description: route.Description,
+		Tags:        route.Tags,
+		Deprecated:  route.Deprecated,
+		Responses:   make(map[string]*Response),
+	}
+
+	op.Parameters = append(op.Parameters, parameters(route.Params, "path", "param")...)
+	// Every templated path parameter must be declared, even without a schema
+	declared := make(map[string]bool)
+	for _, p := range op.Parameters {
+		declared[p.Name] = true
+	}
+	for _, name := range pathParams {
+		if !declared[name] {
+			op.Parameters = append(op.Parameters, &Parameter{Name: name, In: "path", Required: true, Schema: Schema{"type": "string"}})
+		}
+	}
+	op.Parameters = append(op.Parameters, parameters(route.Query, "query", "query")...)
+	op.Parameters = append(op.Parameters, parameters(route.Headers, "header", "header")...)
+	op.Parameters = append(op.Parameters, parameters(route.Cookies, "cookie", "cookie")...)
+
+	if route.Body != nil {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content: map[string]*MediaType{
+				"application/json": {Schema: partSchema(route.Body)},
+			},
+		}
+	}
+
+	for status, resp := range route.Responses {
+		op.Responses[strconv.Itoa(status)] = response(status, resp)
+	}
+	if len(op.Responses) == 0 {
+		op.Responses["200"] = &Response{Description: http.StatusText(http.StatusOK)}
+	}
+	// Hooks reject invalid requests before the handler runs
+	if hasSchema(route) && op.Responses["400"] == nil {
+		op.Responses["400"] = &Response{Description: "Validation failed"}
+	}
+	return op
+}
+
+// parameters lists the parameters of a request part
+// With a zog schema its shape decides the parameters; otherwise the fields of the type with tag do
+func parameters(part *core.RouteSchema, in, tag string) []*Parameter {
+	if part == nil {
+		return nil
+	}
+
+	var params []*Parameter
+	if schema, ok := part.Schema.(z.ZogSchema); ok && reflect.TypeOf(schema) == structSchemaType {
+		for _, f := range shapeFields(reflect.ValueOf(schema), deref(part.Type), tag) {
+			params = append(params, &Parameter{
+				Name:     f.name,
+				In:       in,
+				Required: f.required || in == "path",
+				Schema:   f.schema,
+			})
+		}
+		return params
+	}
+
+	t := deref(part.Type)
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		value, ok := f.Tag.Lookup(tag)
+		name, _, _ := strings.Cut(value, ",")
+		if !ok || name == "" || name == "-" || !f.IsExported() {
+			continue
+		}
+		params = append(params, &Parameter{
+			Name:     strings.TrimSpace(name),
+			In:       in,
+			Required: in == "path",
+			Schema:   TypeSchema(f.Type),
+		})
+	}
+	return params
+}
+
+// partSchema converts a request part to JSON Schema
+func partSchema(part *core.RouteSchema) Schema {
+	if schema, ok := part.Schema.(z.ZogSchema); ok {
+		return ZogSchema(schema, part.Type)
+	}
+	return TypeSchema(part.Type)
+}
+
+// response documents a response; the body is a JSON Schema, a zog schema or a value of the Go type sent
+func response(status int, resp *core.RouteResponse) *Response {
+	description := resp.Description
+	if description == "" {
+		description = http.StatusText(status)
+	}
+	out := &Response{Description: description}
+	if resp.Body == nil {
+		return out
+	}
+
+	var schema Schema
+	switch body := resp.Body.(type) {
+	case Schema:
+		schema = body
+	case z.ZogSchema:
+		schema = ZogSchema(body, nil)
+	default:
+		schema = TypeSchema(reflect.TypeOf(resp.Body))
+	}
+	contentType := resp.ContentType
+	if contentType == "" {
+		contentType = "application/json"
+	}
+	out.Content = map[string]*MediaType{contentType: {Schema: schema}}
+	return out
+}
+
+// hasSchema reports whether any part of the route's request is validated by a zog schema
+func hasSchema(route *core.Route) bool {
+	for _, part := range []*core.RouteSchema{route.Params, route.Query, route.Headers, route.Cookies, route.Body} {
+		if part != nil && part.Schema != nil {
+			return true
+		}
+	}
+	return false
+}
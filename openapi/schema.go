@@ -0,0 +1,391 @@
+package openapi
+
+import (
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	z "github.com/Oudwins/zog"
+)
+
+// Schemas are read from zog's unexported fields with reflection, since zog has no introspection API
+// Unknown schema kinds (custom tests, preprocessors...) become the empty schema, which accepts anything
+var (
+	structSchemaType  = reflect.TypeOf(&z.StructSchema{})
+	sliceSchemaType   = reflect.TypeOf(&z.SliceSchema{})
+	pointerSchemaType = reflect.TypeOf(&z.PointerSchema{})
+	timeSchemaType    = reflect.TypeOf(&z.TimeSchema{})
+	timeType          = reflect.TypeOf(time.Time{})
+)
+
+// field is a struct field described by a zog shape
+type field struct {
+	name     string
+	schema   Schema
+	required bool
+}
+
+// ZogSchema converts a zog schema to JSON Schema
+// t is the Go type the schema parses into; it names object properties by their json tags
+// and may be nil, in which case the shape keys are used
+func ZogSchema(schema z.ZogSchema, t reflect.Type) Schema {
+	return zogSchema(reflect.ValueOf(schema), t)
+}
+
+// zogSchema converts the zog schema held in v, a pointer to one of zog's schema structs
+func zogSchema(v reflect.Value, t reflect.Type) Schema {
+	for v.IsValid() && v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if !v.IsValid() || v.Kind() != reflect.Pointer || v.IsNil() {
+		return Schema{}
+	}
+	t = deref(t)
+
+	switch v.Type() {
+	case structSchemaType:
+		schema := Schema{"type": "object"}
+		fields := shapeFields(v, t, "json")
+		properties := Schema{}
+		var required []string
+		for _, f := range fields {
+			properties[f.name] = f.schema
+			if f.required {
+				required = append(required, f.name)
+			}
+		}
+		schema["properties"] = properties
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+		return schema
+	case sliceSchemaType:
+		var elem reflect.Type
+		if t != nil && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+			elem = t.Elem()
+		}
+		schema := Schema{"type": "array", "items": zogSchema(v.Elem().FieldByName("schema"), elem)}
+		applyTests(schema, v, map[string]string{"min": "minItems", "max": "maxItems"})
+		return schema
+	case pointerSchemaType:
+		return zogSchema(v.Elem().FieldByName("schema"), t)
+	case timeSchemaType:
+		return Schema{"type": "string", "format": "date-time"}
+	}
+
+	name := v.Type().Elem().Name()
+	switch {
+	case strings.HasPrefix(name, "StringSchema["):
+		schema := Schema{"type": "string"}
+		applyTests(schema, v, map[string]string{"min": "minLength", "max": "maxLength"})
+		applyDefault(schema, v)
+		return schema
+	case strings.HasPrefix(name, "NumberSchema["):
+		schema := Schema{"type": "integer"}
+		if isFloat(t) || (t == nil && strings.Contains(name, "float")) {
+			schema["type"] = "number"
+		}
+		applyTests(schema, v, nil)
+		applyDefault(schema, v)
+		return schema
+	case strings.HasPrefix(name, "BoolSchema["):
+		schema := Schema{"type": "boolean"}
+		applyDefault(schema, v)
+		return schema
+	case strings.HasPrefix(name, "BoxedSchema["), strings.HasPrefix(name, "PreprocessSchema["):
+		// The wrapped schema describes the value after unboxing or preprocessing
+		return zogSchema(v.Elem().FieldByName("schema"), nil)
+	}
+	return Schema{}
+}
+
+// shapeFields lists the fields of a zog struct schema in the order of t's fields
+// Names come from tag (with options such as omitempty stripped), then the zog tag, then the shape key,
+// mirroring how zog's data providers look fields up
+func shapeFields(v reflect.Value, t reflect.Type, tag string) []field {
+	shape := v.Elem().FieldByName("schema")
+	keys := make(map[string]reflect.Value, shape.Len())
+	for _, key := range shape.MapKeys() {
+		keys[key.String()] = shape.MapIndex(key)
+	}
+
+	var fields []field
+	if t != nil && t.Kind() == reflect.Struct {
+		for i := 0; i < t.NumField(); i++ {
+			structField := t.Field(i)
+			key := shapeKey(structField.Name, keys)
+			if key == "" {
+				continue
+			}
+			fields = append(fields, shapeField(keys[key], structField.Type, fieldName(structField, tag, key)))
+			delete(keys, key)
+		}
+	}
+
+	// Keys without a matching struct field keep their shape name
+	rest := make([]string, 0, len(keys))
+	for key := range keys {
+		rest = append(rest, key)
+	}
+	sort.Strings(rest)
+	for _, key := range rest {
+		fields = append(fields, shapeField(keys[key], nil, key))
+	}
+	return fields
+}
+
+// shapeKey returns the key of keys zog matches to the struct field name
+// zog upper-cases the first letter of a key to find its field, so "iD" and "ID" both match ID
+func shapeKey(fieldName string, keys map[string]reflect.Value) string {
+	for key := range keys {
+		if key == fieldName || (key != "" && strings.ToUpper(key[:1])+key[1:] == fieldName) {
+			return key
+		}
+	}
+	return ""
+}
+
+func shapeField(v reflect.Value, t reflect.Type, name string) field {
+	return field{name: name, schema: zogSchema(v, t), required: isRequired(v)}
+}
+
+// fieldName names a struct field the way the hook reading it would
+// useheaders also accepts the json tag, and a header tag lists names of which the first is documented
+func fieldName(f reflect.StructField, tag, fallback string) string {
+	tags := []string{tag}
+	if tag == "header" {
+		tags = append(tags, "json")
+	}
+	for _, tag := range tags {
+		if value, ok := f.Tag.Lookup(tag); ok {
+			if name, _, _ := strings.Cut(value, ","); name != "" && name != "-" {
+				return strings.TrimSpace(name)
+			}
+		}
+	}
+	if value, ok := f.Tag.Lookup("zog"); ok && value != "" {
+		return value
+	}
+	return fallback
+}
+
+// isRequired reports whether a zog schema has a required test
+func isRequired(v reflect.Value) bool {
+	for v.IsValid() && v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if !v.IsValid() || v.Kind() != reflect.Pointer || v.IsNil() {
+		return false
+	}
+	required := v.Elem().FieldByName("required")
+	return required.IsValid() && required.Kind() == reflect.Pointer && !required.IsNil()
+}
+
+// applyTests adds the constraints of a schema's tests
+// limits renames the min/max keywords, which mean lengths for strings and item counts for slices
+func applyTests(schema Schema, v reflect.Value, limits map[string]string) {
+	processors := v.Elem().FieldByName("processors")
+	if !processors.IsValid() {
+		return
+	}
+	for i := 0; i < processors.Len(); i++ {
+		test := processors.Index(i)
+		for test.Kind() == reflect.Interface || test.Kind() == reflect.Pointer {
+			if test.IsNil() {
+				break
+			}
+			test = test.Elem()
+		}
+		if test.Kind() != reflect.Struct || !test.FieldByName("IssueCode").IsValid() {
+			// Transforms change the value and don't constrain it
+			continue
+		}
+		code := test.FieldByName("IssueCode").String()
+		param := testParam(test.FieldByName("Params"), code)
+
+		switch code {
+		case "min", "max":
+			if keyword, ok := limits[code]; ok {
+				schema[keyword] = param
+			}
+		case "len":
+			if limits != nil {
+				schema[limits["min"]] = param
+				schema[limits["max"]] = param
+			}
+		case "gt":
+			schema["exclusiveMinimum"] = param
+		case "gte":
+			schema["minimum"] = param
+		case "lt":
+			schema["exclusiveMaximum"] = param
+		case "lte":
+			schema["maximum"] = param
+		case "eq":
+			schema["const"] = param
+		case "one_of_options":
+			schema["enum"] = param
+		case "email":
+			schema["format"] = "email"
+		case "url":
+			schema["format"] = "uri"
+		case "uuid":
+			schema["format"] = "uuid"
+		case "match":
+			schema["pattern"] = param
+		case "prefix":
+			if s, ok := param.(string); ok {
+				schema["pattern"] = "^" + regexp.QuoteMeta(s)
+			}
+		case "suffix":
+			if s, ok := param.(string); ok {
+				schema["pattern"] = regexp.QuoteMeta(s) + "$"
+			}
+		}
+	}
+}
+
+// applyDefault adds the schema's default value
+func applyDefault(schema Schema, v reflect.Value) {
+	defaultVal := v.Elem().FieldByName("defaultVal")
+	if defaultVal.IsValid() && defaultVal.Kind() == reflect.Pointer && !defaultVal.IsNil() {
+		if value := plainValue(defaultVal.Elem()); value != nil {
+			schema["default"] = value
+		}
+	}
+}
+
+// testParam returns the parameter a test stores under its issue code, e.g. 3 for Min(3)
+func testParam(params reflect.Value, code string) any {
+	if !params.IsValid() || params.IsNil() {
+		return nil
+	}
+	value := params.MapIndex(reflect.ValueOf(code))
+	if !value.IsValid() {
+		return nil
+	}
+	return plainValue(value)
+}
+
+// plainValue copies a value read through unexported fields into a plain Go value
+// reflect forbids calling Interface on such values, so they are rebuilt from their kind
+func plainValue(v reflect.Value) any {
+	for v.Kind() == reflect.Interface || v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.Slice, reflect.Array:
+		values := make([]any, v.Len())
+		for i := range values {
+			values[i] = plainValue(v.Index(i))
+		}
+		return values
+	}
+	return nil
+}
+
+// TypeSchema converts a Go type to JSON Schema, naming struct properties by their json tags
+func TypeSchema(t reflect.Type) Schema {
+	return typeSchema(t, map[reflect.Type]bool{})
+}
+
+func typeSchema(t reflect.Type, seen map[reflect.Type]bool) Schema {
+	t = deref(t)
+	if t == nil {
+		return Schema{}
+	}
+	if t == timeType {
+		return Schema{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return Schema{"type": "string"}
+	case reflect.Bool:
+		return Schema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Schema{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return Schema{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// encoding/json sends byte slices as base64
+			return Schema{"type": "string", "contentEncoding": "base64"}
+		}
+		return Schema{"type": "array", "items": typeSchema(t.Elem(), seen)}
+	case reflect.Map:
+		return Schema{"type": "object", "additionalProperties": typeSchema(t.Elem(), seen)}
+	case reflect.Struct:
+		if seen[t] {
+			// Recursive types are left open rather than expanded forever
+			return Schema{"type": "object"}
+		}
+		seen[t] = true
+		defer delete(seen, t)
+
+		properties := Schema{}
+		structProperties(t, properties, seen)
+		return Schema{"type": "object", "properties": properties}
+	}
+	return Schema{}
+}
+
+// structProperties adds the JSON properties of t, flattening embedded structs like encoding/json
+// Fields bound from the path, query, headers or cookies are not part of the body and are skipped
+func structProperties(t reflect.Type, properties Schema, seen map[reflect.Type]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, hasTag := f.Tag.Lookup("json")
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "-" || isBoundElsewhere(f) {
+			continue
+		}
+		if f.Anonymous && !hasTag && deref(f.Type).Kind() == reflect.Struct {
+			structProperties(deref(f.Type), properties, seen)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		properties[name] = typeSchema(f.Type, seen)
+	}
+}
+
+func isBoundElsewhere(f reflect.StructField) bool {
+	for _, tag := range []string{"param", "query", "header", "cookie"} {
+		if _, ok := f.Tag.Lookup(tag); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func deref(t reflect.Type) reflect.Type {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+func isFloat(t reflect.Type) bool {
+	return t != nil && (t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64)
+}
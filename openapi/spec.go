@@ -0,0 +1,499 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/hemant-mann/lumora-go/core"
+	"github.com/hemant-mann/lumora-go/middleware/logging"
+)
+
+// methods lists the operation keys of a path item, in the order they are registered
+var methods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// Spec is an OpenAPI 3.x document loaded for spec-first development
+// Bind handlers to its operations by operationId, then Mount registers them with request validation:
+//
+//	spec, err := openapi.LoadSpec("api.yaml")
+//	spec.Bind("getUser", getUser)
+//	spec.Bind("createUser", createUser)
+//	spec.Mount(app, nil)
+type Spec struct {
+	Info       Info
+	Operations []*SpecOperation
+
+	byID     map[string]*SpecOperation
+	bindings []*binding
+}
+
+// SpecOperation is an operation of a loaded document
+// Parameters include the ones declared on the path, and $refs are already resolved
+type SpecOperation struct {
+	*Operation
+	Method string
+	Path   string
+}
+
+type binding struct {
+	operationID string
+	handler     core.Handler
+	middlewares []core.Middleware
+}
+
+// LoadSpec reads an OpenAPI 3.x document from a YAML or JSON file
+func LoadSpec(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSpec(data)
+}
+
+// ParseSpec parses an OpenAPI 3.x document in YAML or JSON
+// Local references such as "#/components/schemas/User" are resolved; external ones are not supported
+func ParseSpec(data []byte) (*Spec, error) {
+	// YAML is a superset of JSON, so both go through the same decoder
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("openapi: %w", err)
+	}
+	var root map[string]any
+	if err := json.Unmarshal(jsonData, &root); err != nil {
+		return nil, fmt.Errorf("openapi: %w", err)
+	}
+	if version, _ := root["openapi"].(string); !strings.HasPrefix(version, "3.") {
+		return nil, fmt.Errorf("openapi: unsupported version %q, expected 3.x", version)
+	}
+	resolved, err := resolveRefs(root, root, nil)
+	if err != nil {
+		return nil, err
+	}
+	root = resolved.(map[string]any)
+
+	spec := &Spec{byID: make(map[string]*SpecOperation)}
+	if err := decode(root["info"], &spec.Info); err != nil {
+		return nil, fmt.Errorf("openapi: info: %w", err)
+	}
+
+	paths, _ := root["paths"].(map[string]any)
+	names := make([]string, 0, len(paths))
+	for name := range paths {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, path := range names {
+		item, _ := paths[path].(map[string]any)
+		var shared []*Parameter
+		if err := decode(item["parameters"], &shared); err != nil {
+			return nil, fmt.Errorf("openapi: %s: %w", path, err)
+		}
+		for _, method := range methods {
+			raw, ok := item[method]
+			if !ok {
+				continue
+			}
+			op := &Operation{}
+			if err := decode(raw, op); err != nil {
+				return nil, fmt.Errorf("openapi: %s %s: %w", strings.ToUpper(method), path, err)
+			}
+			op.Parameters = mergeParameters(shared, op.Parameters)
+
+			specOp := &SpecOperation{Operation: op, Method: strings.ToUpper(method), Path: path}
+			spec.Operations = append(spec.Operations, specOp)
+			if op.OperationID != "" {
+				if _, exists := spec.byID[op.OperationID]; exists {
+					return nil, fmt.Errorf("openapi: duplicate operationId %q", op.OperationID)
+				}
+				spec.byID[op.OperationID] = specOp
+			}
+		}
+	}
+	return spec, nil
+}
+
+// Operation returns the operation with operationID, or nil
+func (s *Spec) Operation(operationID string) *SpecOperation {
+	return s.byID[operationID]
+}
+
+// Bind binds a handler and route middleware to the operation with operationID
+// Unknown operation ids are reported by Mount
+func (s *Spec) Bind(operationID string, handler core.Handler, middlewares ...core.Middleware) *Spec {
+	s.bindings = append(s.bindings, &binding{operationID: operationID, handler: handler, middlewares: middlewares})
+	return s
+}
+
+// Mount registers every bound operation on app, validating requests against the spec before the handler runs
+// Operations without a handler and handlers bound to unknown operations are logged as warnings;
+// a nil logger uses logging.DefaultLogger
+func (s *Spec) Mount(app core.App, logger logging.Logger) {
+	if logger == nil {
+		logger = &logging.DefaultLogger{}
+	}
+
+	bound := make(map[string]bool)
+	for _, b := range s.bindings {
+		op := s.byID[b.operationID]
+		if op == nil {
+			logger.Log("WARN", "Handler bound to an operation that is not in the OpenAPI document", map[string]any{
+				"operationId": b.operationID,
+			})
+			continue
+		}
+		bound[b.operationID] = true
+
+		middlewares := append([]core.Middleware{validator(op)}, b.middlewares...)
+		route := app.Handle(op.Method, routePattern(op.Path), b.handler, middlewares...)
+		route.WithOperationID(op.OperationID).
+			WithSummary(op.Summary).
+			WithDescription(op.Description).
+			WithTags(op.Tags...)
+		route.Deprecated = op.Deprecated
+		for status, resp := range op.Responses {
+			code, err := strconv.Atoi(status)
+			if err != nil {
+				continue
+			}
+			route.WithResponseType(code, resp.Description, firstContentType(resp.Content), contentSchema(resp.Content))
+		}
+	}
+
+	for _, op := range s.Operations {
+		if !bound[op.OperationID] {
+			logger.Log("WARN", "OpenAPI operation has no handler", map[string]any{
+				"operationId": op.OperationID,
+				"method":      op.Method,
+				"path":        op.Path,
+			})
+		}
+	}
+}
+
+// Validator returns the middleware Mount uses to validate requests for operationID
+// Use it to register an operation's route yourself; it panics if the operation is unknown
+func (s *Spec) Validator(operationID string) core.Middleware {
+	op := s.byID[operationID]
+	if op == nil {
+		panic(fmt.Sprintf("openapi: unknown operationId %q", operationID))
+	}
+	return validator(op)
+}
+
+// validator validates the parameters and body of a request against op
+// Failures are returned together as a core.ValidationError with paths prefixed like userequest's,
+// e.g. "params.id", "query.page" or "body.email"; a body in an undeclared content type gets a 415
+func validator(op *SpecOperation) core.Middleware {
+	return func(next core.Handler) core.Handler {
+		return func(ctx core.Context) (*core.Response, error) {
+			fields := validateParameters(ctx, op.Parameters)
+			bodyFields, err := validateBody(ctx, op.RequestBody)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, bodyFields...)
+			if len(fields) > 0 {
+				return nil, &core.ValidationError{Fields: fields}
+			}
+			return next(ctx)
+		}
+	}
+}
+
+// sections names parameter locations like userequest names its sections
+var sections = map[string]string{"path": "params", "query": "query", "header": "headers", "cookie": "cookies"}
+
+func validateParameters(ctx core.Context, params []*Parameter) []core.FieldError {
+	var fields []core.FieldError
+	for _, param := range params {
+		path := sections[param.In] + "." + param.Name
+		values := parameterValues(ctx, param)
+		if len(values) == 0 {
+			if param.Required || param.In == "path" {
+				fields = append(fields, core.FieldError{Path: path, Code: "required", Message: "is required"})
+			}
+			continue
+		}
+
+		value, ok := coerce(values, param.Schema, param.In == "query")
+		if !ok {
+			fields = append(fields, core.FieldError{
+				Path:    path,
+				Code:    "invalid_type",
+				Message: fmt.Sprintf("invalid value %q", values[0]),
+			})
+			continue
+		}
+		for _, field := range param.Schema.Validate(value) {
+			field.Path = joinPath(path, field.Path)
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// parameterValues returns the raw values of a parameter, or nil if it was not sent
+func parameterValues(ctx core.Context, param *Parameter) []string {
+	req := ctx.Request()
+	switch param.In {
+	case "path":
+		if value := ctx.Param(param.Name); value != "" {
+			return []string{value}
+		}
+	case "query":
+		return req.URL.Query()[param.Name]
+	case "header":
+		return req.Header.Values(param.Name)
+	case "cookie":
+		if cookie, err := req.Cookie(param.Name); err == nil {
+			return []string{cookie.Value}
+		}
+	}
+	return nil
+}
+
+// coerce converts raw parameter values to the JSON value the schema describes
+// Arrays come from repeated query keys or, elsewhere, from comma-separated values
+func coerce(values []string, schema Schema, repeated bool) (any, bool) {
+	if primaryType(schema) == "array" {
+		if !repeated {
+			values = strings.Split(values[0], ",")
+		}
+		items := schemaOf(schema["items"])
+		out := make([]any, len(values))
+		for i, v := range values {
+			value, ok := coerceValue(v, primaryType(items))
+			if !ok {
+				return nil, false
+			}
+			out[i] = value
+		}
+		return out, true
+	}
+	return coerceValue(values[0], primaryType(schema))
+}
+
+func coerceValue(value, typ string) (any, bool) {
+	switch typ {
+	case "integer":
+		// ParseInt rejects forms like "1e3" and values that don't fit in an int64
+		n, err := strconv.ParseInt(value, 10, 64)
+		return n, err == nil
+	case "number":
+		n, err := strconv.ParseFloat(value, 64)
+		return n, err == nil
+	case "boolean":
+		b, err := strconv.ParseBool(value)
+		return b, err == nil
+	}
+	return value, true
+}
+
+// primaryType returns the first non-null type of a schema
+func primaryType(schema Schema) string {
+	for _, t := range schemaTypes(schema["type"]) {
+		if t != "null" {
+			return t
+		}
+	}
+	return ""
+}
+
+// validateBody checks the request body against the schema of its content type
+// Only JSON bodies are read and validated against a schema; other declared types, such as
+// uploads, are passed through unread so they can still be streamed
+func validateBody(ctx core.Context, body *RequestBody) ([]core.FieldError, error) {
+	if body == nil {
+		return nil, nil
+	}
+	if ctx.Request().ContentLength == 0 {
+		return missingBody(body), nil
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(ctx.Header("Content-Type"))
+	media, ok := matchContent(body.Content, mediaType)
+	if !ok {
+		return nil, core.UnsupportedMediaType(fmt.Sprintf("Content-Type %q is not accepted", mediaType))
+	}
+	if media == nil || !isJSON(mediaType) {
+		return nil, nil
+	}
+
+	// RequestBody is capped by the app's body limit
+	data, err := ctx.RequestBody()
+	if err != nil {
+		if httpErr := core.GetHTTPError(err); httpErr != nil {
+			return nil, httpErr
+		}
+		return nil, core.BadRequest("Failed to read request body")
+	}
+	if len(data) == 0 {
+		return missingBody(body), nil
+	}
+
+	var value any
+	if err := core.GetJSONEngine(ctx).Unmarshal(data, &value); err != nil {
+		return []core.FieldError{{Path: "body", Code: "invalid_json", Message: "request body is not valid JSON"}}, nil
+	}
+	fields := media.Schema.Validate(value)
+	for i := range fields {
+		fields[i].Path = joinPath("body", fields[i].Path)
+	}
+	return fields, nil
+}
+
+// missingBody reports an empty body as missing when the operation requires one
+func missingBody(body *RequestBody) []core.FieldError {
+	if body.Required {
+		return []core.FieldError{{Path: "body", Code: "required", Message: "is required"}}
+	}
+	return nil
+}
+
+// matchContent finds the declared media type for mediaType, accepting wildcards such as "application/*"
+func matchContent(content map[string]*MediaType, mediaType string) (*MediaType, bool) {
+	if media, ok := content[mediaType]; ok {
+		return media, true
+	}
+	if major, _, found := strings.Cut(mediaType, "/"); found {
+		if media, ok := content[major+"/*"]; ok {
+			return media, true
+		}
+	}
+	media, ok := content["*/*"]
+	return media, ok
+}
+
+func isJSON(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// firstContentType returns a response's content type, preferring JSON
+func firstContentType(content map[string]*MediaType) string {
+	types := make([]string, 0, len(content))
+	for mediaType := range content {
+		if isJSON(mediaType) {
+			return mediaType
+		}
+		types = append(types, mediaType)
+	}
+	sort.Strings(types)
+	if len(types) == 0 {
+		return ""
+	}
+	return types[0]
+}
+
+// contentSchema returns the schema of a response's content, or nil for an empty response
+func contentSchema(content map[string]*MediaType) any {
+	if media := content[firstContentType(content)]; media != nil && media.Schema != nil {
+		return media.Schema
+	}
+	return nil
+}
+
+// routePattern converts an OpenAPI path template to a route pattern, e.g. "/users/{id}" to "/users/:id"
+func routePattern(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			segments[i] = ":" + segment[1:len(segment)-1]
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// mergeParameters adds the path item's parameters an operation doesn't override
+func mergeParameters(shared, own []*Parameter) []*Parameter {
+	merged := append([]*Parameter(nil), own...)
+	for _, param := range shared {
+		overridden := false
+		for _, p := range own {
+			if p.Name == param.Name && p.In == param.In {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			merged = append(merged, param)
+		}
+	}
+	return merged
+}
+
+// resolveRefs replaces local {"$ref": "#/..."} objects with their targets
+// stack holds the references being resolved, so recursive schemas end in an empty schema
+func resolveRefs(node any, root map[string]any, stack []string) (any, error) {
+	switch v := node.(type) {
+	case map[string]any:
+		if ref, ok := v["$ref"].(string); ok {
+			for _, seen := range stack {
+				if seen == ref {
+					return map[string]any{}, nil
+				}
+			}
+			target, err := lookupRef(root, ref)
+			if err != nil {
+				return nil, err
+			}
+			return resolveRefs(target, root, append(stack, ref))
+		}
+		out := make(map[string]any, len(v))
+		for key, value := range v {
+			resolved, err := resolveRefs(value, root, stack)
+			if err != nil {
+				return nil, err
+			}
+			out[key] = resolved
+		}
+		return out, nil
+	case []any:
+		out := make([]any, len(v))
+		for i, value := range v {
+			resolved, err := resolveRefs(value, root, stack)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = resolved
+		}
+		return out, nil
+	}
+	return node, nil
+}
+
+// lookupRef follows a JSON pointer such as "#/components/schemas/User"
+func lookupRef(root map[string]any, ref string) (any, error) {
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, fmt.Errorf("openapi: unsupported reference %q, only local references are supported", ref)
+	}
+	var node any = root
+	for _, token := range strings.Split(ref[2:], "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		object, ok := node.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("openapi: reference %q not found", ref)
+		}
+		if node, ok = object[token]; !ok {
+			return nil, fmt.Errorf("openapi: reference %q not found", ref)
+		}
+	}
+	return node, nil
+}
+
+// decode converts a decoded JSON value into out
+func decode(value any, out any) error {
+	if value == nil {
+		return nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
@@ -0,0 +1,76 @@
+package openapi
+
+import (
+	"embed"
+	"html/template"
+	"path"
+	"strings"
+
+	"github.com/hemant-mann/lumora-go/core"
+)
+
+// assets holds Swagger UI, embedded so the documentation page works offline and under a strict
+// Content-Security-Policy; it is served next to the page at DocsPath
+//
+//go:embed assets/swagger-ui-bundle.js assets/swagger-ui.css assets/swagger-init.js
+var assets embed.FS
+
+// assetTypes are the content types of the embedded assets
+var assetTypes = map[string]string{
+	".css": "text/css; charset=utf-8",
+	".js":  "text/javascript; charset=utf-8",
+}
+
+// The documentation page loads Swagger UI from the embedded assets and points it at the document
+var page = template.Must(template.New("swagger").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>{{.Title}}</title>
+  <link rel="stylesheet" href="{{.AssetsPath}}/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui" data-url="{{.SpecURL}}"></div>
+  <script src="{{.AssetsPath}}/swagger-ui-bundle.js"></script>
+  <script src="{{.AssetsPath}}/swagger-init.js"></script>
+</body>
+</html>
+`))
+
+// docsPage renders the documentation page
+func docsPage(options *Options) (string, error) {
+	var out strings.Builder
+	err := page.Execute(&out, struct {
+		Title      string
+		SpecURL    string
+		AssetsPath string
+	}{options.Title, options.Path, assetsPath(options)})
+	if err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// assetsPath returns the path the embedded assets are served under
+func assetsPath(options *Options) string {
+	return strings.TrimRight(options.DocsPath, "/") + "/assets"
+}
+
+// mountAssets registers a route for each embedded asset
+func mountAssets(app core.App, options *Options, middlewares []core.Middleware) {
+	entries, _ := assets.ReadDir("assets")
+	for _, entry := range entries {
+		data, _ := assets.ReadFile("assets/" + entry.Name())
+		body := string(data)
+		contentType := assetTypes[path.Ext(entry.Name())]
+		app.Get(assetsPath(options)+"/"+entry.Name(), func(ctx core.Context) (*core.Response, error) {
+			resp := core.NewResponse().
+				WithStatus(200).
+				WithHeader("Content-Type", contentType).
+				WithHeader("Cache-Control", "public, max-age=86400").
+				WithBody(body)
+			return resp, nil
+		}, middlewares...)
+	}
+}
@@ -0,0 +1,343 @@
+package openapi
+
+import (
+	"fmt"
+	"math"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hemant-mann/lumora-go/core"
+)
+
+// Validate checks a decoded JSON value against the schema and returns every failure
+// It supports the keywords OpenAPI documents commonly use: type (and 3.0's nullable), enum, const,
+// numeric and length limits, pattern, format, items, properties, required, additionalProperties,
+// allOf, anyOf and oneOf. Paths are dotted, e.g. "items.0.name"; issue codes match zog's
+func (s Schema) Validate(value any) []core.FieldError {
+	return s.validate(value, "", nil)
+}
+
+func (s Schema) validate(value any, path string, errs []core.FieldError) []core.FieldError {
+	if len(s) == 0 {
+		return errs
+	}
+	fail := func(code, message string, params map[string]any) {
+		errs = append(errs, core.FieldError{Path: path, Code: code, Message: message, Params: params})
+	}
+
+	if value == nil && s["nullable"] == true {
+		return errs
+	}
+	if types := schemaTypes(s["type"]); len(types) > 0 && !matchesType(value, types) {
+		fail("invalid_type", fmt.Sprintf("must be of type %s", strings.Join(types, " or ")), map[string]any{"type": s["type"]})
+		return errs
+	}
+
+	if enum, ok := s["enum"].([]any); ok && !containsValue(enum, value) {
+		fail("one_of_options", "must be one of the allowed values", map[string]any{"one_of_options": enum})
+	}
+	if constant, ok := s["const"]; ok && !equalValues(constant, value) {
+		fail("eq", fmt.Sprintf("must be equal to %v", constant), map[string]any{"eq": constant})
+	}
+
+	switch v := value.(type) {
+	case float64:
+		errs = s.validateNumber(v, path, errs)
+	case int64:
+		// Integer parameters are int64; limits are compared as float64, like JSON numbers
+		errs = s.validateNumber(float64(v), path, errs)
+	case string:
+		errs = s.validateString(v, path, errs)
+	case []any:
+		if n, ok := number(s["minItems"]); ok && float64(len(v)) < n {
+			fail("min", fmt.Sprintf("must contain at least %v items", n), map[string]any{"min": n})
+		}
+		if n, ok := number(s["maxItems"]); ok && float64(len(v)) > n {
+			fail("max", fmt.Sprintf("must contain at most %v items", n), map[string]any{"max": n})
+		}
+		if items := schemaOf(s["items"]); items != nil {
+			for i, item := range v {
+				errs = items.validate(item, joinPath(path, fmt.Sprint(i)), errs)
+			}
+		}
+	case map[string]any:
+		errs = s.validateObject(v, path, errs)
+	}
+
+	if all, ok := s["allOf"].([]any); ok {
+		for _, sub := range all {
+			errs = schemaOf(sub).validate(value, path, errs)
+		}
+	}
+	if anyOf, ok := s["anyOf"].([]any); ok && countMatches(anyOf, value) == 0 {
+		fail("invalid_type", "must match at least one schema", nil)
+	}
+	if oneOf, ok := s["oneOf"].([]any); ok && countMatches(oneOf, value) != 1 {
+		fail("invalid_type", "must match exactly one schema", nil)
+	}
+	return errs
+}
+
+func (s Schema) validateNumber(v float64, path string, errs []core.FieldError) []core.FieldError {
+	fail := func(code, message string, limit float64) {
+		errs = append(errs, core.FieldError{Path: path, Code: code, Message: message, Params: map[string]any{code: limit}})
+	}
+	// OpenAPI 3.0 spells exclusive limits as booleans next to minimum and maximum
+	if n, ok := number(s["minimum"]); ok {
+		if s["exclusiveMinimum"] == true {
+			if v <= n {
+				fail("gt", fmt.Sprintf("must be greater than %v", n), n)
+			}
+		} else if v < n {
+			fail("gte", fmt.Sprintf("must be greater than or equal to %v", n), n)
+		}
+	}
+	if n, ok := number(s["maximum"]); ok {
+		if s["exclusiveMaximum"] == true {
+			if v >= n {
+				fail("lt", fmt.Sprintf("must be less than %v", n), n)
+			}
+		} else if v > n {
+			fail("lte", fmt.Sprintf("must be less than or equal to %v", n), n)
+		}
+	}
+	if n, ok := number(s["exclusiveMinimum"]); ok && v <= n {
+		fail("gt", fmt.Sprintf("must be greater than %v", n), n)
+	}
+	if n, ok := number(s["exclusiveMaximum"]); ok && v >= n {
+		fail("lt", fmt.Sprintf("must be less than %v", n), n)
+	}
+	if n, ok := number(s["multipleOf"]); ok && n != 0 && math.Mod(v, n) != 0 {
+		fail("multiple_of", fmt.Sprintf("must be a multiple of %v", n), n)
+	}
+	return errs
+}
+
+func (s Schema) validateString(v string, path string, errs []core.FieldError) []core.FieldError {
+	fail := func(code, message string, params map[string]any) {
+		errs = append(errs, core.FieldError{Path: path, Code: code, Message: message, Params: params})
+	}
+	length := float64(len([]rune(v)))
+	if n, ok := number(s["minLength"]); ok && length < n {
+		fail("min", fmt.Sprintf("must be at least %v characters long", n), map[string]any{"min": n})
+	}
+	if n, ok := number(s["maxLength"]); ok && length > n {
+		fail("max", fmt.Sprintf("must be at most %v characters long", n), map[string]any{"max": n})
+	}
+	if pattern, ok := s["pattern"].(string); ok {
+		if re := compilePattern(pattern); re != nil && !re.MatchString(v) {
+			fail("match", "must match the pattern "+pattern, map[string]any{"match": pattern})
+		}
+	}
+	if format, ok := s["format"].(string); ok && !matchesFormat(format, v) {
+		code := format
+		if format == "uri" {
+			code = "url"
+		}
+		fail(code, "must be a valid "+format, nil)
+	}
+	return errs
+}
+
+func (s Schema) validateObject(v map[string]any, path string, errs []core.FieldError) []core.FieldError {
+	for _, key := range schemaTypes(s["required"]) {
+		if _, present := v[key]; !present {
+			errs = append(errs, core.FieldError{Path: joinPath(path, key), Code: "required", Message: "is required"})
+		}
+	}
+
+	properties := schemaOf(s["properties"])
+	keys := make([]string, 0, len(v))
+	for key := range v {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if property, ok := properties[key]; ok {
+			errs = schemaOf(property).validate(v[key], joinPath(path, key), errs)
+			continue
+		}
+		if s["additionalProperties"] == false {
+			errs = append(errs, core.FieldError{Path: joinPath(path, key), Code: "unknown_field", Message: "is not allowed"})
+		} else if additional := schemaOf(s["additionalProperties"]); additional != nil {
+			errs = additional.validate(v[key], joinPath(path, key), errs)
+		}
+	}
+	return errs
+}
+
+// schemaOf converts a decoded schema to Schema; anything else is the empty schema
+func schemaOf(value any) Schema {
+	switch s := value.(type) {
+	case Schema:
+		return s
+	case map[string]any:
+		return s
+	}
+	return nil
+}
+
+// schemaTypes reads a keyword holding a string or a list of strings, such as type or required
+// OpenAPI 3.1 allows a list of types such as ["string", "null"]
+func schemaTypes(value any) []string {
+	switch t := value.(type) {
+	case string:
+		return []string{t}
+	case []string:
+		return t
+	case []any:
+		types := make([]string, 0, len(t))
+		for _, item := range t {
+			if s, ok := item.(string); ok {
+				types = append(types, s)
+			}
+		}
+		return types
+	}
+	return nil
+}
+
+func matchesType(value any, types []string) bool {
+	for _, t := range types {
+		switch t {
+		case "null":
+			if value == nil {
+				return true
+			}
+		case "boolean":
+			if _, ok := value.(bool); ok {
+				return true
+			}
+		case "string":
+			if _, ok := value.(string); ok {
+				return true
+			}
+		case "number":
+			switch value.(type) {
+			case float64, int64:
+				return true
+			}
+		case "integer":
+			if _, ok := value.(int64); ok {
+				return true
+			}
+			if n, ok := value.(float64); ok && n == math.Trunc(n) {
+				return true
+			}
+		case "array":
+			if _, ok := value.([]any); ok {
+				return true
+			}
+		case "object":
+			if _, ok := value.(map[string]any); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func countMatches(schemas []any, value any) int {
+	matches := 0
+	for _, sub := range schemas {
+		if len(schemaOf(sub).validate(value, "", nil)) == 0 {
+			matches++
+		}
+	}
+	return matches
+}
+
+// formats checks the string formats; unknown formats are accepted
+var formats = map[string]func(string) bool{
+	"email": func(s string) bool {
+		addr, err := mail.ParseAddress(s)
+		return err == nil && addr.Address == s
+	},
+	"uuid": regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`).MatchString,
+	"uri": func(s string) bool {
+		u, err := url.Parse(s)
+		return err == nil && u.Scheme != ""
+	},
+	"date-time": func(s string) bool {
+		_, err := time.Parse(time.RFC3339, s)
+		return err == nil
+	},
+	"date": func(s string) bool {
+		_, err := time.Parse(time.DateOnly, s)
+		return err == nil
+	},
+}
+
+func matchesFormat(format, value string) bool {
+	check, ok := formats[format]
+	return !ok || check(value)
+}
+
+var patterns sync.Map
+
+// compilePattern compiles and caches a schema pattern; patterns Go can't compile are skipped
+func compilePattern(pattern string) *regexp.Regexp {
+	if re, ok := patterns.Load(pattern); ok {
+		return re.(*regexp.Regexp)
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil
+	}
+	patterns.Store(pattern, re)
+	return re
+}
+
+func containsValue(values []any, value any) bool {
+	for _, v := range values {
+		if equalValues(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// equalValues compares decoded JSON values, treating all numbers as float64
+func equalValues(a, b any) bool {
+	if x, ok := number(a); ok {
+		y, ok := number(b)
+		return ok && x == y
+	}
+	return fmt.Sprint(a) == fmt.Sprint(b)
+}
+
+// number reads a numeric keyword, which may be any Go number type in generated schemas
+func number(value any) (float64, bool) {
+	switch n := value.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+func joinPath(path, key string) string {
+	if key == "" {
+		return path
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
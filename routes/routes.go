@@ -0,0 +1,118 @@
+// Package routes lists an app's registered routes, as a table for code review or as JSON from a debug endpoint
+//
+//	func main() {
+//		app := nethttp.New()
+//		// "go run . routes" prints the route table instead of starting the server
+//		if routes.Command(app, registerRoutes) {
+//			return
+//		}
+//		app.Start(":8080")
+//	}
+package routes
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/hemant-mann/lumora-go/core"
+)
+
+// Entry describes a registered route
+type Entry struct {
+	Method      string   `json:"method"`
+	Path        string   `json:"path"`
+	Name        string   `json:"name,omitempty"`
+	Handler     string   `json:"handler"`
+	Middlewares []string `json:"middlewares"`
+	Summary     string   `json:"summary,omitempty"`
+	OperationID string   `json:"operationId,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Deprecated  bool     `json:"deprecated,omitempty"`
+	Responses   []int    `json:"responses,omitempty"`
+}
+
+// List describes routes in the order they were registered
+func List(routes []*core.Route) []Entry {
+	entries := make([]Entry, len(routes))
+	for i, route := range routes {
+		var responses []int
+		for status := range route.Responses {
+			responses = append(responses, status)
+		}
+		sort.Ints(responses)
+
+		entries[i] = Entry{
+			Method:      route.Method,
+			Path:        route.Path,
+			Name:        route.Name,
+			Handler:     route.Handler,
+			Middlewares: route.Middlewares,
+			Summary:     route.Summary,
+			OperationID: route.OperationID,
+			Tags:        route.Tags,
+			Deprecated:  route.Deprecated,
+			Responses:   responses,
+		}
+	}
+	return entries
+}
+
+// WriteTable writes routes to w as an aligned table with one route per line
+func WriteTable(w io.Writer, routes []*core.Route) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "METHOD\tPATH\tNAME\tHANDLER\tMIDDLEWARE")
+	for _, entry := range List(routes) {
+		handler := entry.Handler
+		if entry.Deprecated {
+			handler += " (deprecated)"
+		}
+		middlewares := strings.Join(entry.Middlewares, ", ")
+		if middlewares == "" {
+			middlewares = "-"
+		}
+		name := entry.Name
+		if name == "" {
+			name = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", entry.Method, entry.Path, name, handler, middlewares)
+	}
+	return tw.Flush()
+}
+
+// Print registers app's routes with register and writes the route table to w
+// The app is not started, so it can be used from tests to keep a golden copy of the table
+func Print(w io.Writer, app core.App, register func(app core.App)) error {
+	register(app)
+	return WriteTable(w, app.Routes())
+}
+
+// Command registers app's routes with register and, when the program is run with the "routes"
+// argument, prints the route table to stdout and returns true
+// Otherwise it returns false and the program starts as usual
+func Command(app core.App, register func(app core.App)) bool {
+	register(app)
+	if len(os.Args) < 2 || os.Args[1] != "routes" {
+		return false
+	}
+	if err := WriteTable(os.Stdout, app.Routes()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+	return true
+}
+
+// Handler serves app's routes as JSON, for a debug endpoint
+// Routes registered after it are included, since the list is built on each request
+//
+//	app.Get("/debug/routes", routes.Handler(app), requireAdmin)
+func Handler(app core.App) core.Handler {
+	return func(ctx core.Context) (*core.Response, error) {
+		resp := core.NewResponse().
+			WithStatus(200).
+			WithBody(List(app.Routes()))
+		return resp, nil
+	}
+}
@@ -0,0 +1,207 @@
+package tus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileStore stores each upload as <id>.bin with its metadata in <id>.info
+// The offset is the size of the .bin file, so it survives restarts without extra bookkeeping
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore creates a filesystem store in dir, creating it if needed
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+// Create writes the info file and an empty content file for a new upload
+func (s *FileStore) Create(ctx context.Context, info Info) error {
+	if !validID(info.ID) {
+		return fmt.Errorf("tus: invalid upload id %q", info.ID)
+	}
+	file, err := os.OpenFile(s.binPath(info.ID), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := s.writeInfo(info); err != nil {
+		os.Remove(s.binPath(info.ID))
+		return err
+	}
+	return nil
+}
+
+// Get reads the info file and derives the offset from the content file
+func (s *FileStore) Get(ctx context.Context, id string) (Info, error) {
+	if !validID(id) {
+		return Info{}, ErrNotFound
+	}
+	data, err := os.ReadFile(s.infoPath(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return Info{}, ErrNotFound
+	}
+	if err != nil {
+		return Info{}, err
+	}
+	var info Info
+	if err := json.Unmarshal(data, &info); err != nil {
+		return Info{}, fmt.Errorf("tus: corrupt info for %q: %w", id, err)
+	}
+	stat, err := os.Stat(s.binPath(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return Info{}, ErrNotFound
+	}
+	if err != nil {
+		return Info{}, err
+	}
+	info.Offset = stat.Size()
+	return info, nil
+}
+
+// WriteChunk appends src to the content file
+func (s *FileStore) WriteChunk(ctx context.Context, id string, offset int64, src io.Reader) (int64, error) {
+	if !validID(id) {
+		return 0, ErrNotFound
+	}
+	file, err := os.OpenFile(s.binPath(id), os.O_WRONLY|os.O_APPEND, 0o644)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, ErrNotFound
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return 0, err
+	}
+	if stat.Size() != offset {
+		return 0, ErrOffsetMismatch
+	}
+
+	n, err := io.Copy(file, src)
+	if errors.Is(err, ErrChecksumMismatch) {
+		// Drop the whole chunk so the client can resend it from the same offset
+		if truncErr := file.Truncate(offset); truncErr != nil {
+			return n, truncErr
+		}
+		return 0, err
+	}
+	return n, err
+}
+
+// Open opens the content file of an upload
+func (s *FileStore) Open(ctx context.Context, id string) (io.ReadCloser, error) {
+	if !validID(id) {
+		return nil, ErrNotFound
+	}
+	file, err := os.Open(s.binPath(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return file, err
+}
+
+// Terminate removes both files of an upload
+func (s *FileStore) Terminate(ctx context.Context, id string) error {
+	if !validID(id) {
+		return ErrNotFound
+	}
+	err := os.Remove(s.infoPath(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(s.binPath(id)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// List returns every upload that has an info file
+func (s *FileStore) List(ctx context.Context) ([]Info, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	var uploads []Info
+	for _, entry := range entries {
+		id, ok := strings.CutSuffix(entry.Name(), ".info")
+		if !ok || entry.IsDir() {
+			continue
+		}
+		info, err := s.Get(ctx, id)
+		if errors.Is(err, ErrNotFound) {
+			// Terminated while listing
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		uploads = append(uploads, info)
+	}
+	return uploads, nil
+}
+
+// writeInfo writes the info file atomically
+func (s *FileStore) writeInfo(info Info) error {
+	data, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(s.dir, ".info-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), s.infoPath(info.ID))
+}
+
+func (s *FileStore) binPath(id string) string {
+	return filepath.Join(s.dir, id+".bin")
+}
+
+func (s *FileStore) infoPath(id string) string {
+	return filepath.Join(s.dir, id+".info")
+}
+
+// validID reports whether id is safe to use as a file name
+func validID(id string) bool {
+	if id == "" || len(id) > 128 {
+		return false
+	}
+	for _, c := range id {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,67 @@
+package tus
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+var (
+	// ErrNotFound is returned by stores for unknown upload IDs
+	ErrNotFound = errors.New("tus: upload not found")
+	// ErrOffsetMismatch is returned when a chunk does not start at the current offset
+	ErrOffsetMismatch = errors.New("tus: offset mismatch")
+	// ErrChecksumMismatch is returned by the chunk reader when Upload-Checksum does not match
+	// Stores must discard the partially written chunk when they see it
+	ErrChecksumMismatch = errors.New("tus: checksum mismatch")
+)
+
+// Info describes a resumable upload
+type Info struct {
+	ID string `json:"id"`
+	// Size is the total length declared by the client in Upload-Length
+	Size int64 `json:"size"`
+	// Offset is the number of bytes received so far
+	Offset int64 `json:"-"`
+	// Metadata holds the decoded Upload-Metadata pairs
+	Metadata  map[string]string `json:"metadata,omitempty"`
+	CreatedAt time.Time         `json:"createdAt"`
+	// ExpiresAt is when an incomplete upload may be removed; zero means never
+	ExpiresAt time.Time `json:"expiresAt,omitempty"`
+}
+
+// Complete reports whether every byte of the upload has been received
+func (i Info) Complete() bool {
+	return i.Offset >= i.Size
+}
+
+// Expired reports whether the upload is incomplete and past its expiry at now
+func (i Info) Expired(now time.Time) bool {
+	return !i.Complete() && !i.ExpiresAt.IsZero() && now.After(i.ExpiresAt)
+}
+
+// Store persists uploads and their chunks
+// Implementations must be safe for concurrent use; the handler never writes
+// the same upload from two requests at once
+type Store interface {
+	// Create registers a new, empty upload
+	Create(ctx context.Context, info Info) error
+
+	// Get returns the upload with its current offset, or ErrNotFound
+	Get(ctx context.Context, id string) (Info, error)
+
+	// WriteChunk appends src to the upload, which must currently be at offset
+	// It returns the number of bytes stored; bytes read before a read error are kept,
+	// except when the error is ErrChecksumMismatch
+	WriteChunk(ctx context.Context, id string, offset int64, src io.Reader) (int64, error)
+
+	// Open opens the content of an upload for reading
+	Open(ctx context.Context, id string) (io.ReadCloser, error)
+
+	// Terminate removes an upload and everything stored for it
+	Terminate(ctx context.Context, id string) error
+
+	// List returns every upload, used to clean up expired ones
+	List(ctx context.Context) ([]Info, error)
+}
@@ -0,0 +1,435 @@
+package tus
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hemant-mann/lumora-go/core"
+)
+
+// Version is the tus protocol version implemented by the handler
+const Version = "1.0.0"
+
+// Extensions lists the tus extensions supported by the handler
+const Extensions = "creation,termination,checksum,expiration"
+
+// StatusChecksumMismatch is the tus status for a chunk whose Upload-Checksum does not match
+const StatusChecksumMismatch = 460
+
+// checksumAlgorithms maps Upload-Checksum algorithm names to hash constructors
+var checksumAlgorithms = map[string]func() hash.Hash{
+	"md5":    md5.New,
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+// Options represents tus handler configuration options
+type Options struct {
+	// Store persists uploads and their chunks
+	Store Store
+	// MaxSize limits Upload-Length and is advertised as Tus-Max-Size; zero means no limit
+	MaxSize int64
+	// Expiration is how long an incomplete upload is kept after creation; zero disables expiration
+	Expiration time.Duration
+	// OnCreate is called before an upload is created; returning an error rejects it
+	OnCreate func(ctx core.Context, info Info) error
+	// OnComplete is called once the last byte of an upload has been stored,
+	// e.g. to enqueue post-processing; an error is returned to the client
+	OnComplete func(ctx core.Context, info Info) error
+}
+
+// DefaultOptions returns default tus options for the given store
+func DefaultOptions(store Store) *Options {
+	return &Options{
+		Store:      store,
+		Expiration: 24 * time.Hour,
+	}
+}
+
+// Handler implements the tus 1.0 resumable upload protocol
+type Handler struct {
+	options *Options
+
+	mu     sync.Mutex
+	locked map[string]bool
+}
+
+// New creates a tus handler
+func New(options *Options) *Handler {
+	if options == nil || options.Store == nil {
+		panic("tus: Options.Store is required")
+	}
+	return &Handler{
+		options: options,
+		locked:  make(map[string]bool),
+	}
+}
+
+// Mount registers the tus endpoints under prefix, e.g. "/files"
+// middlewares run for every tus request, after the app-level middleware
+func (h *Handler) Mount(app core.App, prefix string, middlewares ...core.Middleware) {
+	prefix = strings.TrimRight(prefix, "/")
+	if prefix == "" {
+		prefix = "/"
+	}
+	uploadPath := strings.TrimRight(prefix, "/") + "/:id"
+
+	app.Handle(http.MethodOptions, prefix, h.withProtocol(h.Options), middlewares...)
+	app.Handle(http.MethodPost, prefix, h.withProtocol(h.Create), middlewares...)
+	app.Handle(http.MethodHead, uploadPath, h.withProtocol(h.Head), middlewares...)
+	app.Handle(http.MethodPatch, uploadPath, h.withProtocol(h.Patch), middlewares...)
+	app.Handle(http.MethodDelete, uploadPath, h.withProtocol(h.Delete), middlewares...)
+}
+
+// withProtocol sets Tus-Resumable on every response and rejects clients speaking another version
+func (h *Handler) withProtocol(next core.Handler) core.Handler {
+	return func(ctx core.Context) (*core.Response, error) {
+		ctx.SetHeader("Tus-Resumable", Version)
+		if ctx.Request().Method != http.MethodOptions && ctx.Header("Tus-Resumable") != Version {
+			ctx.SetHeader("Tus-Version", Version)
+			return nil, core.NewError(http.StatusPreconditionFailed, "Unsupported tus version")
+		}
+		return next(ctx)
+	}
+}
+
+// Options describes the server's capabilities
+func (h *Handler) Options(ctx core.Context) (*core.Response, error) {
+	algorithms := make([]string, 0, len(checksumAlgorithms))
+	for name := range checksumAlgorithms {
+		algorithms = append(algorithms, name)
+	}
+	sort.Strings(algorithms)
+
+	resp := core.NewResponse().
+		WithStatus(http.StatusNoContent).
+		WithHeader("Tus-Version", Version).
+		WithHeader("Tus-Extension", Extensions).
+		WithHeader("Tus-Checksum-Algorithm", strings.Join(algorithms, ","))
+	if h.options.MaxSize > 0 {
+		resp.WithHeader("Tus-Max-Size", strconv.FormatInt(h.options.MaxSize, 10))
+	}
+	return resp, nil
+}
+
+// Create starts a new upload (creation extension)
+func (h *Handler) Create(ctx core.Context) (*core.Response, error) {
+	if ctx.Header("Upload-Defer-Length") != "" {
+		return nil, core.NewError(http.StatusBadRequest, "Upload-Defer-Length is not supported")
+	}
+	size, err := strconv.ParseInt(ctx.Header("Upload-Length"), 10, 64)
+	if err != nil || size < 0 {
+		return nil, core.NewError(http.StatusBadRequest, "Invalid Upload-Length")
+	}
+	if h.options.MaxSize > 0 && size > h.options.MaxSize {
+		return nil, core.NewError(http.StatusRequestEntityTooLarge, "Upload-Length exceeds Tus-Max-Size")
+	}
+	metadata, err := parseMetadata(ctx.Header("Upload-Metadata"))
+	if err != nil {
+		return nil, core.WrapError(http.StatusBadRequest, "Invalid Upload-Metadata", err)
+	}
+
+	id, err := newID()
+	if err != nil {
+		return nil, err
+	}
+	info := Info{
+		ID:        id,
+		Size:      size,
+		Metadata:  metadata,
+		CreatedAt: time.Now().UTC(),
+	}
+	if h.options.Expiration > 0 {
+		info.ExpiresAt = info.CreatedAt.Add(h.options.Expiration)
+	}
+
+	if h.options.OnCreate != nil {
+		if err := h.options.OnCreate(ctx, info); err != nil {
+			return nil, err
+		}
+	}
+	if err := h.options.Store.Create(ctx.Context(), info); err != nil {
+		return nil, core.WrapError(http.StatusInternalServerError, "Failed to create upload", err)
+	}
+
+	resp := core.NewResponse().
+		WithStatus(http.StatusCreated).
+		WithHeader("Location", strings.TrimRight(ctx.Request().URL.Path, "/")+"/"+id)
+	if !info.ExpiresAt.IsZero() {
+		resp.WithHeader("Upload-Expires", info.ExpiresAt.Format(http.TimeFormat))
+	}
+
+	// An empty upload is complete as soon as it exists
+	if size == 0 && h.options.OnComplete != nil {
+		if err := h.options.OnComplete(ctx, info); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// Head reports the current offset of an upload
+func (h *Handler) Head(ctx core.Context) (*core.Response, error) {
+	info, err := h.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := core.NewResponse().
+		WithHeader("Cache-Control", "no-store").
+		WithHeader("Upload-Offset", strconv.FormatInt(info.Offset, 10)).
+		WithHeader("Upload-Length", strconv.FormatInt(info.Size, 10))
+	if len(info.Metadata) > 0 {
+		resp.WithHeader("Upload-Metadata", formatMetadata(info.Metadata))
+	}
+	if !info.ExpiresAt.IsZero() && !info.Complete() {
+		resp.WithHeader("Upload-Expires", info.ExpiresAt.Format(http.TimeFormat))
+	}
+	return resp, nil
+}
+
+// Patch appends a chunk to an upload
+func (h *Handler) Patch(ctx core.Context) (*core.Response, error) {
+	if ctx.Header("Content-Type") != "application/offset+octet-stream" {
+		return nil, core.NewError(http.StatusUnsupportedMediaType, "Content-Type must be application/offset+octet-stream")
+	}
+	offset, err := strconv.ParseInt(ctx.Header("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		return nil, core.NewError(http.StatusBadRequest, "Invalid Upload-Offset")
+	}
+
+	var checksum *checksumReader
+	if header := ctx.Header("Upload-Checksum"); header != "" {
+		checksum, err = newChecksumReader(header)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	id := ctx.Param("id")
+	if !h.lock(id) {
+		return nil, core.NewError(http.StatusLocked, "Upload is locked by another request")
+	}
+	defer h.unlock(id)
+
+	info, err := h.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if offset != info.Offset {
+		return nil, core.NewError(http.StatusConflict, "Upload-Offset does not match the current offset")
+	}
+
+	remaining := info.Size - info.Offset
+	if contentLength := ctx.Header("Content-Length"); contentLength != "" {
+		if n, err := strconv.ParseInt(contentLength, 10, 64); err == nil && n > remaining {
+			return nil, core.NewError(http.StatusRequestEntityTooLarge, "Chunk exceeds Upload-Length")
+		}
+	}
+
+	src := io.LimitReader(ctx.BodyReader(), remaining)
+	if checksum != nil {
+		checksum.src = src
+		src = checksum
+	}
+	written, writeErr := h.options.Store.WriteChunk(ctx.Context(), id, offset, src)
+	info.Offset += written
+
+	if writeErr != nil {
+		switch {
+		case errors.Is(writeErr, ErrChecksumMismatch):
+			return nil, core.NewError(StatusChecksumMismatch, "Checksum mismatch")
+		case errors.Is(writeErr, ErrOffsetMismatch):
+			return nil, core.NewError(http.StatusConflict, "Upload-Offset does not match the current offset")
+		case errors.Is(writeErr, ErrNotFound):
+			return nil, core.NewError(http.StatusNotFound, "Upload not found")
+		}
+		// The bytes that arrived are kept, so the client can resume from the new offset
+		ctx.SetHeader("Upload-Offset", strconv.FormatInt(info.Offset, 10))
+		return nil, core.WrapError(http.StatusInternalServerError, "Failed to store chunk", writeErr)
+	}
+
+	resp := core.NewResponse().
+		WithStatus(http.StatusNoContent).
+		WithHeader("Upload-Offset", strconv.FormatInt(info.Offset, 10))
+	if !info.ExpiresAt.IsZero() && !info.Complete() {
+		resp.WithHeader("Upload-Expires", info.ExpiresAt.Format(http.TimeFormat))
+	}
+
+	if written > 0 && info.Complete() && h.options.OnComplete != nil {
+		if err := h.options.OnComplete(ctx, info); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// Delete terminates an upload (termination extension)
+func (h *Handler) Delete(ctx core.Context) (*core.Response, error) {
+	id := ctx.Param("id")
+	if !h.lock(id) {
+		return nil, core.NewError(http.StatusLocked, "Upload is locked by another request")
+	}
+	defer h.unlock(id)
+
+	err := h.options.Store.Terminate(ctx.Context(), id)
+	if errors.Is(err, ErrNotFound) {
+		return nil, core.NewError(http.StatusNotFound, "Upload not found")
+	}
+	if err != nil {
+		return nil, core.WrapError(http.StatusInternalServerError, "Failed to terminate upload", err)
+	}
+	return core.NewResponse().WithStatus(http.StatusNoContent), nil
+}
+
+// CleanupExpired terminates incomplete uploads past their expiry and returns how many were removed
+// Run it periodically, e.g. from a time.Ticker
+func (h *Handler) CleanupExpired(ctx context.Context) (int, error) {
+	uploads, err := h.options.Store.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+	now := time.Now()
+	removed := 0
+	for _, info := range uploads {
+		if !info.Expired(now) || !h.lock(info.ID) {
+			continue
+		}
+		err := h.options.Store.Terminate(ctx, info.ID)
+		h.unlock(info.ID)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// get loads the upload named by the id path parameter, mapping store errors to HTTP errors
+func (h *Handler) get(ctx core.Context) (Info, error) {
+	info, err := h.options.Store.Get(ctx.Context(), ctx.Param("id"))
+	if errors.Is(err, ErrNotFound) {
+		return Info{}, core.NewError(http.StatusNotFound, "Upload not found")
+	}
+	if err != nil {
+		return Info{}, core.WrapError(http.StatusInternalServerError, "Failed to load upload", err)
+	}
+	if info.Expired(time.Now()) {
+		return Info{}, core.NewError(http.StatusGone, "Upload expired")
+	}
+	return info, nil
+}
+
+// lock marks an upload as in use; it returns false if another request holds it
+func (h *Handler) lock(id string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.locked[id] {
+		return false
+	}
+	h.locked[id] = true
+	return true
+}
+
+func (h *Handler) unlock(id string) {
+	h.mu.Lock()
+	delete(h.locked, id)
+	h.mu.Unlock()
+}
+
+// checksumReader hashes a chunk and fails with ErrChecksumMismatch at EOF if the digest differs
+type checksumReader struct {
+	src      io.Reader
+	hash     hash.Hash
+	expected []byte
+}
+
+// newChecksumReader parses an Upload-Checksum header like "sha1 <base64 digest>"
+func newChecksumReader(header string) (*checksumReader, error) {
+	name, encoded, ok := strings.Cut(header, " ")
+	if !ok {
+		return nil, core.NewError(http.StatusBadRequest, "Invalid Upload-Checksum")
+	}
+	newHash, ok := checksumAlgorithms[name]
+	if !ok {
+		return nil, core.NewError(http.StatusBadRequest, "Unsupported checksum algorithm")
+	}
+	expected, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, core.WrapError(http.StatusBadRequest, "Invalid Upload-Checksum", err)
+	}
+	return &checksumReader{hash: newHash(), expected: expected}, nil
+}
+
+func (r *checksumReader) Read(p []byte) (int, error) {
+	n, err := r.src.Read(p)
+	r.hash.Write(p[:n])
+	if err == io.EOF && !bytes.Equal(r.hash.Sum(nil), r.expected) {
+		return n, ErrChecksumMismatch
+	}
+	return n, err
+}
+
+// parseMetadata decodes an Upload-Metadata header: comma separated "key base64value" pairs
+func parseMetadata(header string) (map[string]string, error) {
+	metadata := make(map[string]string)
+	if strings.TrimSpace(header) == "" {
+		return metadata, nil
+	}
+	for _, pair := range strings.Split(header, ",") {
+		key, encoded, _ := strings.Cut(strings.TrimSpace(pair), " ")
+		if key == "" {
+			return nil, errors.New("empty metadata key")
+		}
+		value, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, err
+		}
+		metadata[key] = string(value)
+	}
+	return metadata, nil
+}
+
+// formatMetadata encodes metadata for the Upload-Metadata header in a stable order
+func formatMetadata(metadata map[string]string) string {
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if metadata[key] == "" {
+			pairs = append(pairs, key)
+			continue
+		}
+		pairs = append(pairs, key+" "+base64.StdEncoding.EncodeToString([]byte(metadata[key])))
+	}
+	return strings.Join(pairs, ",")
+}
+
+func newID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b[:]), nil
+}
@@ -0,0 +1,31 @@
+package upload
+
+import (
+	"context"
+	"io"
+)
+
+// Storage is the destination for streamed uploads
+// Implementations must be safe for concurrent use
+type Storage interface {
+	// Create starts writing a new object under key
+	// Nothing is visible under key until the returned Writer is committed
+	Create(ctx context.Context, key string) (Writer, error)
+
+	// Open opens a stored object for reading
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete removes a stored object
+	Delete(ctx context.Context, key string) error
+}
+
+// Writer receives the content of a single upload
+type Writer interface {
+	io.Writer
+
+	// Commit finishes the upload and makes the object visible under its key
+	Commit() error
+
+	// Abort discards everything written so far
+	Abort() error
+}
@@ -0,0 +1,248 @@
+package upload
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"path"
+	"strconv"
+
+	"github.com/hemant-mann/lumora-go/core"
+)
+
+// maxFieldBytes limits the size of each text field read while looking for the file part
+const maxFieldBytes = 1 << 20
+
+// Options represents upload configuration options
+type Options struct {
+	// Storage receives the uploaded content
+	Storage Storage
+	// Key returns the storage key for an upload; defaults to a random hex name keeping the file extension
+	Key func(ctx core.Context, filename string) (string, error)
+	// MaxSize limits the size of a single upload; zero means no limit
+	MaxSize int64
+	// Quota returns the number of bytes the caller may still store; nil means no quota
+	// Uploads are rejected with 413 once it returns zero or less
+	Quota func(ctx core.Context) (int64, error)
+	// OnProgress is called as content is written
+	OnProgress func(Progress)
+	// ProgressInterval is the minimum number of bytes between OnProgress calls
+	ProgressInterval int64
+}
+
+// DefaultOptions returns default upload options for the given storage
+func DefaultOptions(storage Storage) *Options {
+	return &Options{
+		Storage:          storage,
+		Key:              RandomKey,
+		ProgressInterval: 1 << 20,
+	}
+}
+
+// Progress reports how much of an upload has been written
+type Progress struct {
+	Key     string
+	Written int64
+	// Total is the expected size, or -1 when unknown
+	Total int64
+}
+
+// Result describes a stored upload
+type Result struct {
+	Key         string
+	Filename    string
+	ContentType string
+	Size        int64
+	// SHA256 is the hex encoded SHA-256 of the content
+	SHA256 string
+	// Fields holds the multipart text fields that preceded the file
+	Fields map[string]string
+}
+
+// RandomKey generates a random storage key, keeping the extension of filename
+func RandomKey(ctx core.Context, filename string) (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b[:]) + path.Ext(filename), nil
+}
+
+// Stream stores the raw request body, e.g. for PUT /artifacts/:name
+func Stream(ctx core.Context, options *Options) (*Result, error) {
+	total := int64(-1)
+	if contentLength := ctx.Header("Content-Length"); contentLength != "" {
+		if n, err := strconv.ParseInt(contentLength, 10, 64); err == nil {
+			total = n
+		}
+	}
+	result := &Result{ContentType: ctx.Header("Content-Type")}
+	return result, store(ctx, ctx.BodyReader(), total, result, options)
+}
+
+// StreamMultipart stores the first file uploaded under field in a multipart body
+// The body is read part by part, so the file is never buffered in memory or on disk
+// Text fields sent before the file are returned in Result.Fields
+func StreamMultipart(ctx core.Context, field string, options *Options) (*Result, error) {
+	mediaType, params, err := mime.ParseMediaType(ctx.Header("Content-Type"))
+	if err != nil || mediaType != "multipart/form-data" {
+		return nil, core.NewError(415, "Content-Type is not multipart/form-data")
+	}
+	if params["boundary"] == "" {
+		return nil, core.NewError(400, "Missing multipart boundary")
+	}
+
+	fields := make(map[string]string)
+	reader := multipart.NewReader(ctx.BodyReader(), params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil, core.NewError(400, fmt.Sprintf("Missing form file %q", field))
+		}
+		if err != nil {
+			return nil, abortError(ctx, err)
+		}
+
+		if part.FormName() == field && part.FileName() != "" {
+			result, err := StreamPart(ctx, part, options)
+			part.Close()
+			if result != nil {
+				result.Fields = fields
+			}
+			return result, err
+		}
+
+		if part.FileName() == "" && part.FormName() != "" {
+			value, err := io.ReadAll(io.LimitReader(part, maxFieldBytes+1))
+			if err != nil {
+				part.Close()
+				return nil, abortError(ctx, err)
+			}
+			if len(value) > maxFieldBytes {
+				part.Close()
+				return nil, core.NewError(413, "Form field too large")
+			}
+			fields[part.FormName()] = string(value)
+		}
+		part.Close()
+	}
+}
+
+// StreamPart stores a single multipart part
+// Use it when iterating a multipart.Reader yourself
+func StreamPart(ctx core.Context, part *multipart.Part, options *Options) (*Result, error) {
+	result := &Result{
+		Filename:    part.FileName(),
+		ContentType: part.Header.Get("Content-Type"),
+	}
+	return result, store(ctx, part, -1, result, options)
+}
+
+// store streams src into storage, hashing and counting as it goes
+func store(ctx core.Context, src io.Reader, total int64, result *Result, options *Options) error {
+	if options == nil || options.Storage == nil {
+		return core.NewError(500, "Upload storage not configured")
+	}
+
+	limit := options.MaxSize
+	if options.Quota != nil {
+		remaining, err := options.Quota(ctx)
+		if err != nil {
+			return err
+		}
+		// With no quota left, reject before reading any of the body
+		if remaining <= 0 {
+			return core.NewError(413, "Upload quota exceeded")
+		}
+		if limit <= 0 || remaining < limit {
+			limit = remaining
+		}
+	}
+	// Reject early when the declared size is already too large
+	if limit > 0 && total > limit {
+		return core.NewError(413, "Upload too large")
+	}
+
+	keyFunc := options.Key
+	if keyFunc == nil {
+		keyFunc = RandomKey
+	}
+	key, err := keyFunc(ctx, result.Filename)
+	if err != nil {
+		return err
+	}
+	result.Key = key
+
+	writer, err := options.Storage.Create(ctx.Context(), key)
+	if err != nil {
+		return core.WrapError(500, "Failed to store upload", err)
+	}
+
+	hash := sha256.New()
+	dst := io.MultiWriter(writer, hash)
+	buf := make([]byte, 32*1024)
+	var written, reported int64
+	for {
+		// Stop as soon as the client goes away or the request is cancelled
+		if err := ctx.Context().Err(); err != nil {
+			writer.Abort()
+			return abortError(ctx, err)
+		}
+
+		n, readErr := src.Read(buf)
+		if n > 0 {
+			written += int64(n)
+			if limit > 0 && written > limit {
+				writer.Abort()
+				return core.NewError(413, "Upload too large")
+			}
+			if _, err := dst.Write(buf[:n]); err != nil {
+				writer.Abort()
+				return core.WrapError(500, "Failed to store upload", err)
+			}
+			if options.OnProgress != nil && written-reported >= options.ProgressInterval {
+				reported = written
+				options.OnProgress(Progress{Key: key, Written: written, Total: total})
+			}
+		}
+		if readErr == io.EOF {
+			// Some servers report a dropped connection as a plain EOF
+			if total >= 0 && written < total {
+				writer.Abort()
+				return abortError(ctx, io.ErrUnexpectedEOF)
+			}
+			break
+		}
+		if readErr != nil {
+			writer.Abort()
+			return abortError(ctx, readErr)
+		}
+	}
+
+	if err := writer.Commit(); err != nil {
+		return core.WrapError(500, "Failed to store upload", err)
+	}
+	if options.OnProgress != nil && reported != written {
+		options.OnProgress(Progress{Key: key, Written: written, Total: total})
+	}
+
+	result.Size = written
+	result.SHA256 = hex.EncodeToString(hash.Sum(nil))
+	return nil
+}
+
+// ErrAborted is wrapped by errors returned when the client disconnects mid-upload
+var ErrAborted = errors.New("upload: aborted")
+
+// abortError converts read failures into an HTTP error wrapping ErrAborted
+func abortError(ctx core.Context, err error) error {
+	if ctx.Context().Err() != nil {
+		err = ctx.Context().Err()
+	}
+	return core.WrapError(400, "Upload aborted", fmt.Errorf("%w: %w", ErrAborted, err))
+}